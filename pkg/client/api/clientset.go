@@ -59,15 +59,38 @@ type BucketsInterface interface {
 	// DeletePolicy removes a policy from an existing bucket.
 	DeletePolicy(ctx context.Context, bucketName string, param map[string]string) error
 
+	// GetPolicyDocument returns current policy for a bucket as a parsed document.
+	GetPolicyDocument(ctx context.Context, bucketName string, param map[string]string) (*model.BucketPolicy, error)
+
+	// UpdatePolicyDocument validates the policy and adds/replaces it on the existing bucket.
+	UpdatePolicyDocument(ctx context.Context, bucketName string, policy model.BucketPolicy, param map[string]string) error
+
 	// Get returns a bucket in the ObjectScale object store
 	Get(ctx context.Context, name string, params map[string]string) (*model.Bucket, error)
 
-	// Create creates a new bucket in the ObjectScale object store
+	// Create creates a new bucket in the ObjectScale object store. Search metadata
+	// can only be enabled at creation, and is validated first.
 	Create(ctx context.Context, createParam model.Bucket) (*model.Bucket, error)
 
 	// Delete deletes bucket from the ObjectScale object store
 	Delete(ctx context.Context, name string, namespace string, emptyBucket bool) error
 
+	// DisableSearchMetadata disables metadata search on the bucket. It cannot be enabled again.
+	DisableSearchMetadata(ctx context.Context, bucketName string, namespace string) error
+
+	// Apply creates the bucket if it is missing, or updates its quota, retention and default group if they differ.
+	// It returns model.ErrImmutableField if fields that cannot be updated differ
+	Apply(ctx context.Context, bucket model.Bucket) (*model.Bucket, model.ApplyResult, error)
+
+	// GetACL returns the access control list of the bucket, including its owner.
+	GetACL(ctx context.Context, bucketName string, namespace string) (*model.BucketACL, error)
+
+	// SetACL validates the access control list and replaces the one of the bucket.
+	SetACL(ctx context.Context, acl model.BucketACL) error
+
+	// UpdateDefaultGroup updates the default group of a file-system enabled bucket and its permissions.
+	UpdateDefaultGroup(ctx context.Context, bucketName string, namespace string, group string, permissions model.DefaultGroupPermissions) error
+
 	// GetQuota Gets the quota for the given bucket and namespace.
 	GetQuota(ctx context.Context, bucketName string, namespace string) (*model.BucketQuotaInfo, error)
 
@@ -76,6 +99,13 @@ type BucketsInterface interface {
 
 	// DeleteQuota Deletes the quota setting for the given bucket and namespace.
 	DeleteQuota(ctx context.Context, bucketName string, namespace string) error
+
+	// GetRetention returns the default retention and the min/max governor of the bucket.
+	GetRetention(ctx context.Context, bucketName string, namespace string) (*model.BucketRetention, error)
+
+	// UpdateRetention updates the default retention and the min/max governor of the bucket,
+	// refusing inconsistent settings and settings loosening those of a compliance-enabled tenant.
+	UpdateRetention(ctx context.Context, bucketName string, namespace string, retention model.BucketRetention) error
 }
 
 // ObjectUserInterface represents an object user resource client interface.
@@ -112,6 +142,9 @@ type AlertPoliciesInterface interface {
 
 	// Update updates an Alert Policy and returns it
 	Update(ctx context.Context, payload model.AlertPolicy, policyName string) (*model.AlertPolicy, error)
+
+	// Apply creates the Alert Policy if it is missing, or updates it if it differs
+	Apply(ctx context.Context, payload model.AlertPolicy) (*model.AlertPolicy, model.ApplyResult, error)
 }
 
 // TenantsInterface represents an tenant resource client interface.
@@ -131,6 +164,10 @@ type TenantsInterface interface {
 	// Update updates a specific tenant (currently only default bucket block size and alias fields supported)
 	Update(ctx context.Context, payload model.TenantUpdate, name string) error
 
+	// Apply creates the tenant if it is missing, or updates its alias and default bucket block size if they differ.
+	// It returns model.ErrImmutableField if fields that cannot be updated differ
+	Apply(ctx context.Context, payload model.TenantCreate) (*model.Tenant, model.ApplyResult, error)
+
 	// GetQuota gets the quota of a tenant
 	GetQuota(ctx context.Context, name string, params map[string]string) (*model.TenantQuota, error)
 
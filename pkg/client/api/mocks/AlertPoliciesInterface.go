@@ -14,6 +14,39 @@ type AlertPoliciesInterface struct {
 	mock.Mock
 }
 
+// Apply provides a mock function with given fields: ctx, payload
+func (_m *AlertPoliciesInterface) Apply(ctx context.Context, payload model.AlertPolicy) (*model.AlertPolicy, model.ApplyResult, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *model.AlertPolicy
+	var r1 model.ApplyResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.AlertPolicy) (*model.AlertPolicy, model.ApplyResult, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.AlertPolicy) *model.AlertPolicy); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AlertPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.AlertPolicy) model.ApplyResult); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Get(1).(model.ApplyResult)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.AlertPolicy) error); ok {
+		r2 = rf(ctx, payload)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, payload
 func (_m *AlertPoliciesInterface) Create(ctx context.Context, payload model.AlertPolicy) (*model.AlertPolicy, error) {
 	ret := _m.Called(ctx, payload)
@@ -14,6 +14,39 @@ type BucketsInterface struct {
 	mock.Mock
 }
 
+// Apply provides a mock function with given fields: ctx, bucket
+func (_m *BucketsInterface) Apply(ctx context.Context, bucket model.Bucket) (*model.Bucket, model.ApplyResult, error) {
+	ret := _m.Called(ctx, bucket)
+
+	var r0 *model.Bucket
+	var r1 model.ApplyResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Bucket) (*model.Bucket, model.ApplyResult, error)); ok {
+		return rf(ctx, bucket)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Bucket) *model.Bucket); ok {
+		r0 = rf(ctx, bucket)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Bucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Bucket) model.ApplyResult); ok {
+		r1 = rf(ctx, bucket)
+	} else {
+		r1 = ret.Get(1).(model.ApplyResult)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.Bucket) error); ok {
+		r2 = rf(ctx, bucket)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, createParam
 func (_m *BucketsInterface) Create(ctx context.Context, createParam model.Bucket) (*model.Bucket, error) {
 	ret := _m.Called(ctx, createParam)
@@ -82,6 +115,20 @@ func (_m *BucketsInterface) DeleteQuota(ctx context.Context, bucketName string,
 	return r0
 }
 
+// DisableSearchMetadata provides a mock function with given fields: ctx, bucketName, namespace
+func (_m *BucketsInterface) DisableSearchMetadata(ctx context.Context, bucketName string, namespace string) error {
+	ret := _m.Called(ctx, bucketName, namespace)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, bucketName, namespace)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, name, params
 func (_m *BucketsInterface) Get(ctx context.Context, name string, params map[string]string) (*model.Bucket, error) {
 	ret := _m.Called(ctx, name, params)
@@ -108,6 +155,32 @@ func (_m *BucketsInterface) Get(ctx context.Context, name string, params map[str
 	return r0, r1
 }
 
+// GetACL provides a mock function with given fields: ctx, bucketName, namespace
+func (_m *BucketsInterface) GetACL(ctx context.Context, bucketName string, namespace string) (*model.BucketACL, error) {
+	ret := _m.Called(ctx, bucketName, namespace)
+
+	var r0 *model.BucketACL
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.BucketACL, error)); ok {
+		return rf(ctx, bucketName, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.BucketACL); ok {
+		r0 = rf(ctx, bucketName, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BucketACL)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bucketName, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPolicy provides a mock function with given fields: ctx, bucketName, param
 func (_m *BucketsInterface) GetPolicy(ctx context.Context, bucketName string, param map[string]string) (string, error) {
 	ret := _m.Called(ctx, bucketName, param)
@@ -132,6 +205,32 @@ func (_m *BucketsInterface) GetPolicy(ctx context.Context, bucketName string, pa
 	return r0, r1
 }
 
+// GetPolicyDocument provides a mock function with given fields: ctx, bucketName, param
+func (_m *BucketsInterface) GetPolicyDocument(ctx context.Context, bucketName string, param map[string]string) (*model.BucketPolicy, error) {
+	ret := _m.Called(ctx, bucketName, param)
+
+	var r0 *model.BucketPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) (*model.BucketPolicy, error)); ok {
+		return rf(ctx, bucketName, param)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string) *model.BucketPolicy); ok {
+		r0 = rf(ctx, bucketName, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BucketPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
+		r1 = rf(ctx, bucketName, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetQuota provides a mock function with given fields: ctx, bucketName, namespace
 func (_m *BucketsInterface) GetQuota(ctx context.Context, bucketName string, namespace string) (*model.BucketQuotaInfo, error) {
 	ret := _m.Called(ctx, bucketName, namespace)
@@ -158,6 +257,32 @@ func (_m *BucketsInterface) GetQuota(ctx context.Context, bucketName string, nam
 	return r0, r1
 }
 
+// GetRetention provides a mock function with given fields: ctx, bucketName, namespace
+func (_m *BucketsInterface) GetRetention(ctx context.Context, bucketName string, namespace string) (*model.BucketRetention, error) {
+	ret := _m.Called(ctx, bucketName, namespace)
+
+	var r0 *model.BucketRetention
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.BucketRetention, error)); ok {
+		return rf(ctx, bucketName, namespace)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.BucketRetention); ok {
+		r0 = rf(ctx, bucketName, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BucketRetention)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, bucketName, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: ctx, params
 func (_m *BucketsInterface) List(ctx context.Context, params map[string]string) (*model.BucketList, error) {
 	ret := _m.Called(ctx, params)
@@ -184,6 +309,34 @@ func (_m *BucketsInterface) List(ctx context.Context, params map[string]string)
 	return r0, r1
 }
 
+// SetACL provides a mock function with given fields: ctx, acl
+func (_m *BucketsInterface) SetACL(ctx context.Context, acl model.BucketACL) error {
+	ret := _m.Called(ctx, acl)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.BucketACL) error); ok {
+		r0 = rf(ctx, acl)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDefaultGroup provides a mock function with given fields: ctx, bucketName, namespace, group, permissions
+func (_m *BucketsInterface) UpdateDefaultGroup(ctx context.Context, bucketName string, namespace string, group string, permissions model.DefaultGroupPermissions) error {
+	ret := _m.Called(ctx, bucketName, namespace, group, permissions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, model.DefaultGroupPermissions) error); ok {
+		r0 = rf(ctx, bucketName, namespace, group, permissions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePolicy provides a mock function with given fields: ctx, bucketName, policy, param
 func (_m *BucketsInterface) UpdatePolicy(ctx context.Context, bucketName string, policy string, param map[string]string) error {
 	ret := _m.Called(ctx, bucketName, policy, param)
@@ -198,6 +351,20 @@ func (_m *BucketsInterface) UpdatePolicy(ctx context.Context, bucketName string,
 	return r0
 }
 
+// UpdatePolicyDocument provides a mock function with given fields: ctx, bucketName, policy, param
+func (_m *BucketsInterface) UpdatePolicyDocument(ctx context.Context, bucketName string, policy model.BucketPolicy, param map[string]string) error {
+	ret := _m.Called(ctx, bucketName, policy, param)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.BucketPolicy, map[string]string) error); ok {
+		r0 = rf(ctx, bucketName, policy, param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateQuota provides a mock function with given fields: ctx, bucketQuota
 func (_m *BucketsInterface) UpdateQuota(ctx context.Context, bucketQuota model.BucketQuotaUpdate) error {
 	ret := _m.Called(ctx, bucketQuota)
@@ -212,6 +379,20 @@ func (_m *BucketsInterface) UpdateQuota(ctx context.Context, bucketQuota model.B
 	return r0
 }
 
+// UpdateRetention provides a mock function with given fields: ctx, bucketName, namespace, retention
+func (_m *BucketsInterface) UpdateRetention(ctx context.Context, bucketName string, namespace string, retention model.BucketRetention) error {
+	ret := _m.Called(ctx, bucketName, namespace, retention)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.BucketRetention) error); ok {
+		r0 = rf(ctx, bucketName, namespace, retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewBucketsInterface interface {
 	mock.TestingT
 	Cleanup(func())
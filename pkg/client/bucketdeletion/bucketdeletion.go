@@ -0,0 +1,218 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bucketdeletion deletes buckets and waits until the server, which
+// empties non-empty buckets in the background, has actually removed them.
+package bucketdeletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// Defaults used when the corresponding Tracker fields are not set.
+const (
+	DefaultTimeout    = 30 * time.Minute
+	DefaultStuckAfter = 10 * time.Minute
+)
+
+// UnknownObjects is the object count reported when it could not be obtained.
+const UnknownObjects int64 = -1
+
+// ErrStuck is matched by the errors returned when a bucket does not go away.
+var ErrStuck = errors.New("bucket deletion stuck")
+
+// Backoff is the policy of the delays between polls: starting at Initial, the
+// delay is multiplied by Factor after every poll, up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultBackoff is the backoff used when Tracker.Backoff is not set.
+var DefaultBackoff = Backoff{Initial: time.Second, Max: 30 * time.Second, Factor: 2}
+
+// next returns the delay following the given one.
+func (b Backoff) next(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return b.Initial
+	}
+
+	delay = time.Duration(float64(delay) * b.Factor)
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+
+	return delay
+}
+
+// Progress is the state of a bucket being deleted.
+type Progress struct {
+	Bucket    string
+	Namespace string
+
+	// Objects is the number of objects left in the bucket, or UnknownObjects
+	Objects int64
+
+	// Elapsed is the time since the bucket was deactivated
+	Elapsed time.Duration
+}
+
+// StuckError is returned when a deactivated bucket still exists after the
+// timeout, or when its object count stopped decreasing.
+type StuckError struct {
+	Progress
+
+	// Reason describes why the bucket is considered stuck
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *StuckError) Error() string {
+	objects := "unknown number of"
+	if e.Objects != UnknownObjects {
+		objects = fmt.Sprint(e.Objects)
+	}
+
+	return fmt.Sprintf("bucket %s/%s: %s after %s with %s objects left: %s",
+		e.Namespace, e.Bucket, ErrStuck, e.Elapsed.Round(time.Second), objects, e.Reason)
+}
+
+// Unwrap returns ErrStuck.
+func (e *StuckError) Unwrap() error {
+	return ErrStuck
+}
+
+// Tracker deletes buckets and waits until they are gone.
+type Tracker struct {
+	// Buckets is used to delete and poll the buckets
+	Buckets api.BucketsInterface
+
+	// ObjectMt, if set, is used to report the number of objects left
+	ObjectMt api.ObjmtInterface
+
+	// Timeout is the maximum time to wait for a bucket to go away
+	Timeout time.Duration
+
+	// StuckAfter is the maximum time the object count may stay the same
+	StuckAfter time.Duration
+
+	// Backoff is the policy of the delays between polls
+	Backoff *Backoff
+
+	// OnProgress, if set, is called after every poll of a bucket still present
+	OnProgress func(Progress)
+}
+
+// DeleteAndWait deactivates the bucket and polls it until it is not found. If
+// emptyBucket is set the server removes the objects of the bucket first. A
+// StuckError is returned if the bucket is still present after Timeout, or if
+// its object count did not change for StuckAfter.
+func (t *Tracker) DeleteAndWait(ctx context.Context, name, namespace string, emptyBucket bool) error {
+	if err := t.Buckets.Delete(ctx, name, namespace, emptyBucket); err != nil {
+		if model.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var (
+		start   = time.Now()
+		backoff = DefaultBackoff
+		delay   time.Duration
+
+		lastObjects = UnknownObjects
+		lastChange  = start
+	)
+
+	if t.Backoff != nil {
+		backoff = *t.Backoff
+	}
+
+	for {
+		_, err := t.Buckets.Get(ctx, name, map[string]string{"namespace": namespace})
+
+		switch {
+		case model.IsNotFound(err):
+			return nil
+		case err != nil && !model.IsRetryable(err):
+			return err
+		}
+
+		now := time.Now()
+		progress := Progress{
+			Bucket:    name,
+			Namespace: namespace,
+			Objects:   t.objects(ctx, name, namespace),
+			Elapsed:   now.Sub(start),
+		}
+
+		if t.OnProgress != nil {
+			t.OnProgress(progress)
+		}
+
+		if progress.Objects != lastObjects {
+			lastObjects, lastChange = progress.Objects, now
+		}
+
+		switch {
+		case progress.Elapsed >= durationOr(t.Timeout, DefaultTimeout):
+			return &StuckError{Progress: progress, Reason: "timed out"}
+		case progress.Objects > 0 && now.Sub(lastChange) >= durationOr(t.StuckAfter, DefaultStuckAfter):
+			return &StuckError{Progress: progress, Reason: "object count not decreasing"}
+		}
+
+		delay = backoff.next(delay)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// objects returns the number of objects left in the bucket, or UnknownObjects
+// if ObjectMt is not set or the metrics are not available.
+func (t *Tracker) objects(ctx context.Context, name, namespace string) int64 {
+	if t.ObjectMt == nil {
+		return UnknownObjects
+	}
+
+	list, err := t.ObjectMt.GetBucketBillingInfo(ctx, namespace, []string{name}, nil)
+	if err != nil {
+		return UnknownObjects
+	}
+
+	for _, info := range list.Info {
+		if info.BucketName == name {
+			return info.ObjectCount()
+		}
+	}
+
+	return UnknownObjects
+}
+
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+
+	return fallback
+}
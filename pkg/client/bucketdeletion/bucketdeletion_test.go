@@ -0,0 +1,185 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketdeletion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/bucketdeletion"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+var (
+	notFound    = model.Error{Description: "bucket not found", Code: model.CodeResourceNotFound}
+	unavailable = model.Error{Description: "service unavailable", Retryable: true}
+	fastBackoff = &bucketdeletion.Backoff{Initial: time.Millisecond, Max: 2 * time.Millisecond, Factor: 2}
+)
+
+func TestTracker(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Gone":            testTrackerGone,
+		"AlreadyDeleted":  testTrackerAlreadyDeleted,
+		"Progress":        testTrackerProgress,
+		"Timeout":         testTrackerTimeout,
+		"NotDecreasing":   testTrackerNotDecreasing,
+		"DeleteError":     testTrackerDeleteError,
+		"RetryableError":  testTrackerRetryableError,
+		"ContextCanceled": testTrackerContextCanceled,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+// billing returns bucket metrics reporting the given object count.
+func billing(name string, objects int64) *model.BucketBillingInfoList {
+	return &model.BucketBillingInfoList{Info: []model.BucketBillingInfo{{
+		BucketName: name,
+		TotalUserObjectMetric: []model.StorageClassBasedCountSize{
+			{StorageClass: "STANDARD", Counts: objects},
+		},
+	}}}
+}
+
+func testTrackerGone(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", map[string]string{"namespace": "ns1"}).
+		Return(&model.Bucket{Name: "bucket1"}, nil).Twice()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(nil, notFound).Once()
+
+	tracker := bucketdeletion.Tracker{Buckets: buckets, Backoff: fastBackoff}
+	require.NoError(t, tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true))
+}
+
+func testTrackerAlreadyDeleted(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", false).Return(notFound).Once()
+
+	tracker := bucketdeletion.Tracker{Buckets: buckets}
+	require.NoError(t, tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", false))
+}
+
+func testTrackerProgress(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(&model.Bucket{Name: "bucket1"}, nil).Times(3)
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(nil, notFound).Once()
+
+	objmt := mocks.NewObjmtInterface(t)
+	objmt.On("GetBucketBillingInfo", mock.Anything, "ns1", []string{"bucket1"}, mock.Anything).
+		Return(billing("bucket1", 20), nil).Once()
+	objmt.On("GetBucketBillingInfo", mock.Anything, "ns1", []string{"bucket1"}, mock.Anything).
+		Return(billing("bucket1", 10), nil).Once()
+	objmt.On("GetBucketBillingInfo", mock.Anything, "ns1", []string{"bucket1"}, mock.Anything).
+		Return(nil, unavailable).Once()
+
+	var objects []int64
+
+	tracker := bucketdeletion.Tracker{
+		Buckets:  buckets,
+		ObjectMt: objmt,
+		Backoff:  fastBackoff,
+		OnProgress: func(p bucketdeletion.Progress) {
+			assert.Equal(t, "bucket1", p.Bucket)
+			assert.Equal(t, "ns1", p.Namespace)
+
+			objects = append(objects, p.Objects)
+		},
+	}
+	require.NoError(t, tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true))
+	assert.Equal(t, []int64{20, 10, bucketdeletion.UnknownObjects}, objects)
+}
+
+func testTrackerTimeout(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(&model.Bucket{Name: "bucket1"}, nil)
+
+	tracker := bucketdeletion.Tracker{Buckets: buckets, Backoff: fastBackoff, Timeout: 10 * time.Millisecond}
+	err := tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true)
+	require.ErrorIs(t, err, bucketdeletion.ErrStuck)
+
+	var stuck *bucketdeletion.StuckError
+	require.ErrorAs(t, err, &stuck)
+	assert.Equal(t, "timed out", stuck.Reason)
+	assert.Equal(t, bucketdeletion.UnknownObjects, stuck.Objects)
+	assert.GreaterOrEqual(t, stuck.Elapsed, 10*time.Millisecond)
+}
+
+func testTrackerNotDecreasing(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(&model.Bucket{Name: "bucket1"}, nil)
+
+	objmt := mocks.NewObjmtInterface(t)
+	objmt.On("GetBucketBillingInfo", mock.Anything, "ns1", []string{"bucket1"}, mock.Anything).
+		Return(billing("bucket1", 7), nil)
+
+	tracker := bucketdeletion.Tracker{
+		Buckets:    buckets,
+		ObjectMt:   objmt,
+		Backoff:    fastBackoff,
+		StuckAfter: 10 * time.Millisecond,
+	}
+	err := tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true)
+
+	var stuck *bucketdeletion.StuckError
+	require.ErrorAs(t, err, &stuck)
+	assert.Equal(t, "object count not decreasing", stuck.Reason)
+	assert.Equal(t, int64(7), stuck.Objects)
+	assert.Contains(t, err.Error(), "with 7 objects left")
+}
+
+func testTrackerDeleteError(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(unavailable).Once()
+
+	tracker := bucketdeletion.Tracker{Buckets: buckets}
+	require.ErrorIs(t, tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true), unavailable)
+}
+
+func testTrackerRetryableError(t *testing.T) {
+	internal := model.Error{Description: "internal exception", Code: model.CodeInternalException}
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(nil, unavailable).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(nil, internal).Once()
+
+	tracker := bucketdeletion.Tracker{Buckets: buckets, Backoff: fastBackoff}
+	require.ErrorIs(t, tracker.DeleteAndWait(context.TODO(), "bucket1", "ns1", true), internal)
+}
+
+func testTrackerContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("Delete", mock.Anything, "bucket1", "ns1", true).Return(nil).Once()
+	buckets.On("Get", mock.Anything, "bucket1", mock.Anything).Return(&model.Bucket{Name: "bucket1"}, nil).Once()
+
+	tracker := bucketdeletion.Tracker{
+		Buckets:    buckets,
+		OnProgress: func(bucketdeletion.Progress) { cancel() },
+	}
+
+	err := tracker.DeleteAndWait(ctx, "bucket1", "ns1", true)
+	require.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,119 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bucketpolicy grants and revokes the access of single principals to
+// buckets, preserving the unrelated statements of the bucket policies.
+package bucketpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// MaxAttempts is the number of times a policy is read, modified and written
+// before giving up because of concurrent modifications.
+const MaxAttempts = 3
+
+// ErrConcurrentModification is returned when the policy of a bucket keeps
+// changing between reading and writing it.
+var ErrConcurrentModification = fmt.Errorf("bucket policy was modified concurrently: %w", model.ErrConflict)
+
+// GrantAccess grants the principal the access level to the bucket, see
+// model.BucketPolicy.GrantAccess.
+func GrantAccess(ctx context.Context, buckets api.BucketsInterface,
+	bucket, principal string, level model.AccessLevel, params map[string]string,
+) error {
+	return Modify(ctx, buckets, bucket, params, func(policy *model.BucketPolicy) (bool, error) {
+		return policy.GrantAccess(bucket, principal, level)
+	})
+}
+
+// RevokeAccess revokes all the access of the principal to the bucket, see
+// model.BucketPolicy.RevokeAccess. The policy is deleted if no statements are
+// left.
+func RevokeAccess(ctx context.Context, buckets api.BucketsInterface,
+	bucket, principal string, params map[string]string,
+) error {
+	return Modify(ctx, buckets, bucket, params, func(policy *model.BucketPolicy) (bool, error) {
+		return policy.RevokeAccess(bucket, principal), nil
+	})
+}
+
+// Modify reads the policy of the bucket, applies fn to it and, if fn reports a
+// change, writes it back. The policy is deleted if no statements are left.
+// Right before writing the policy is read again, and if it was modified in the
+// meantime the whole operation is retried, up to MaxAttempts times.
+func Modify(ctx context.Context, buckets api.BucketsInterface, bucket string, params map[string]string,
+	fn func(policy *model.BucketPolicy) (bool, error),
+) error {
+	for attempt := 0; attempt < MaxAttempts; attempt++ {
+		original, err := buckets.GetPolicy(ctx, bucket, params)
+		if err != nil {
+			return err
+		}
+
+		policy, err := model.ParseBucketPolicy(original)
+		if err != nil {
+			return err
+		}
+
+		changed, err := fn(policy)
+		if err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+
+		current, err := buckets.GetPolicy(ctx, bucket, params)
+		if err != nil {
+			return err
+		}
+
+		if !samePolicy(original, current) {
+			continue
+		}
+
+		if len(policy.Statement) == 0 {
+			return buckets.DeletePolicy(ctx, bucket, params)
+		}
+
+		// Unrelated statements accepted by the server are not validated again.
+		return buckets.UpdatePolicy(ctx, bucket, policy.String(), params)
+	}
+
+	return fmt.Errorf("bucket %s: %w", bucket, ErrConcurrentModification)
+}
+
+// samePolicy reports whether both documents hold the same policy, regardless
+// of formatting.
+func samePolicy(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	pa, err := model.ParseBucketPolicy(a)
+	if err != nil {
+		return false
+	}
+
+	pb, err := model.ParseBucketPolicy(b)
+	if err != nil {
+		return false
+	}
+
+	return pa.String() == pb.String()
+}
@@ -0,0 +1,308 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketpolicy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/api/mocks"
+	"github.com/dell/goobjectscale/pkg/client/bucketpolicy"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+const unrelated = `{"Version":"2012-10-17","Statement":[{"Sid":"admin","Effect":"Allow",` +
+	`"Principal":{"AWS":"admin"},"Action":"s3:*","Resource":"arn:aws:s3:::bucket1"}]}`
+
+// foreign has statements with principals other than users and accounts.
+const foreign = `{"Version":"2012-10-17","Statement":[{"Sid":"logs","Effect":"Allow",` +
+	`"Principal":{"Service":"logging.s3.amazonaws.com","Custom":["a","b"]},"Action":"s3:PutObject",` +
+	`"Resource":"arn:aws:s3:::bucket1/*"},{"Sid":"protect","Effect":"Deny",` +
+	`"NotPrincipal":{"AWS":"admin","CanonicalUser":"0123abcd"},"Action":"s3:DeleteBucket","Resource":"arn:aws:s3:::bucket1"}]}`
+
+func TestBucketPolicy(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"GrantNew":         testBucketPolicyGrantNew,
+		"GrantMerge":       testBucketPolicyGrantMerge,
+		"GrantUnchanged":   testBucketPolicyGrantUnchanged,
+		"GrantLevel":       testBucketPolicyGrantLevel,
+		"GrantDeny":        testBucketPolicyGrantDeny,
+		"GrantStale":       testBucketPolicyGrantStale,
+		"RevokePreserves":  testBucketPolicyRevokePreserves,
+		"ForeignPreserved": testBucketPolicyForeignPreserved,
+		"RevokeDelete":     testBucketPolicyRevokeDelete,
+		"Race":             testBucketPolicyRace,
+		"Conflict":         testBucketPolicyConflict,
+		"GetError":         testBucketPolicyGetError,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+// written returns the policy captured from the UpdatePolicy call of the mock.
+func written(t *testing.T, buckets *mocks.BucketsInterface) *model.BucketPolicy {
+	t.Helper()
+
+	for _, call := range buckets.Calls {
+		if call.Method == "UpdatePolicy" {
+			policy, err := model.ParseBucketPolicy(call.Arguments.String(2))
+			require.NoError(t, err)
+
+			return policy
+		}
+	}
+
+	require.Fail(t, "policy not written")
+
+	return nil
+}
+
+func statement(policy *model.BucketPolicy, sid string) *model.PolicyStatement {
+	for i := range policy.Statement {
+		if policy.Statement[i].Sid == sid {
+			return &policy.Statement[i]
+		}
+	}
+
+	return nil
+}
+
+func testBucketPolicyGrantNew(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return("", nil).Twice()
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", model.AccessRead, nil)
+	require.NoError(t, err)
+
+	policy := written(t, buckets)
+	require.NoError(t, policy.Validate())
+	require.Len(t, policy.Statement, 1)
+
+	s := statement(policy, model.AccessRead.Sid("bucket1"))
+	require.NotNil(t, s)
+	assert.Equal(t, model.StringList{"user1"}, s.Principal.AWS)
+	assert.Equal(t, model.StringList(model.AccessRead.Actions()), s.Action)
+	assert.Equal(t, model.StringList{model.BucketARN("bucket1"), model.ObjectsARN("bucket1")}, s.Resource)
+}
+
+func testBucketPolicyGrantMerge(t *testing.T) {
+	policy, err := model.ParseBucketPolicy(unrelated)
+	require.NoError(t, err)
+
+	_, err = policy.GrantAccess("bucket1", "user1", model.AccessWrite)
+	require.NoError(t, err)
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(policy.String(), nil).Twice()
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err = bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user2", model.AccessWrite, nil)
+	require.NoError(t, err)
+
+	policy = written(t, buckets)
+	require.Len(t, policy.Statement, 2)
+	assert.Equal(t, model.StringList{"admin"}, statement(policy, "admin").Principal.AWS)
+	assert.Equal(t, model.StringList{"user1", "user2"}, statement(policy, model.AccessWrite.Sid("bucket1")).Principal.AWS)
+}
+
+func testBucketPolicyGrantUnchanged(t *testing.T) {
+	policy := &model.BucketPolicy{}
+
+	_, err := policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(policy.String(), nil).Twice()
+
+	err = bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", model.AccessRead, nil)
+	require.NoError(t, err)
+
+	err = bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", "Admin", nil)
+	require.ErrorIs(t, err, model.ErrInvalidPolicy)
+}
+
+func testBucketPolicyGrantLevel(t *testing.T) {
+	policy := &model.BucketPolicy{}
+
+	_, err := policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+
+	_, err = policy.GrantAccess("bucket1", "user2", model.AccessRead)
+	require.NoError(t, err)
+
+	changed, err := policy.GrantAccess("bucket1", "user1", model.AccessReadWrite)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	assert.Equal(t, model.StringList{"user2"}, statement(policy, model.AccessRead.Sid("bucket1")).Principal.AWS)
+	assert.Equal(t, model.StringList{"user1"}, statement(policy, model.AccessReadWrite.Sid("bucket1")).Principal.AWS)
+}
+
+func testBucketPolicyGrantDeny(t *testing.T) {
+	sid := model.AccessRead.Sid("bucket1")
+	policy := &model.BucketPolicy{
+		Version: model.PolicyVersion,
+		Statement: []model.PolicyStatement{{
+			Sid:          sid,
+			Effect:       model.EffectDeny,
+			Principal:    &model.PolicyPrincipal{AWS: model.StringList{"user1"}},
+			NotPrincipal: &model.PolicyPrincipal{AWS: model.StringList{"admin"}},
+			Action:       model.AccessRead.Actions(),
+			Resource:     model.StringList{model.BucketARN("bucket1"), model.ObjectsARN("bucket1")},
+		}},
+	}
+
+	changed, err := policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, policy.Validate())
+
+	s := statement(policy, sid)
+	assert.Equal(t, model.EffectAllow, s.Effect)
+	assert.Nil(t, s.NotPrincipal)
+	assert.Equal(t, model.StringList{"user1"}, s.Principal.AWS)
+
+	changed, err = policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func testBucketPolicyGrantStale(t *testing.T) {
+	sid := model.AccessWrite.Sid("bucket1")
+	policy := &model.BucketPolicy{
+		Version: model.PolicyVersion,
+		Statement: []model.PolicyStatement{{
+			Sid:       sid,
+			Effect:    model.EffectAllow,
+			Principal: &model.PolicyPrincipal{AWS: model.StringList{"user1"}},
+			Action:    model.StringList{"s3:PutObject"},
+			Resource:  model.StringList{model.ObjectsARN("bucket1")},
+		}},
+	}
+
+	changed, err := policy.GrantAccess("bucket1", "user1", model.AccessWrite)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	s := statement(policy, sid)
+	assert.Equal(t, model.StringList(model.AccessWrite.Actions()), s.Action)
+	assert.Equal(t, model.StringList{model.BucketARN("bucket1"), model.ObjectsARN("bucket1")}, s.Resource)
+	assert.Equal(t, model.StringList{"user1"}, s.Principal.AWS)
+}
+
+func testBucketPolicyRevokePreserves(t *testing.T) {
+	policy, err := model.ParseBucketPolicy(unrelated)
+	require.NoError(t, err)
+
+	_, err = policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(policy.String(), nil).Twice()
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", unrelated, mock.Anything).Return(nil).Once()
+
+	err = bucketpolicy.RevokeAccess(context.TODO(), buckets, "bucket1", "user1", nil)
+	require.NoError(t, err)
+
+	// Revoking again does not write anything.
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(unrelated, nil).Once()
+
+	err = bucketpolicy.RevokeAccess(context.TODO(), buckets, "bucket1", "user1", nil)
+	require.NoError(t, err)
+}
+
+func testBucketPolicyForeignPreserved(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(foreign, nil).Twice()
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", model.AccessRead, nil)
+	require.NoError(t, err)
+
+	granted := written(t, buckets)
+	require.Len(t, granted.Statement, 3)
+
+	// Revoking the access again writes back the original statements.
+	buckets = mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(granted.String(), nil).Twice()
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err = bucketpolicy.RevokeAccess(context.TODO(), buckets, "bucket1", "user1", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, foreign, written(t, buckets).String())
+}
+
+func testBucketPolicyRevokeDelete(t *testing.T) {
+	policy := &model.BucketPolicy{}
+
+	_, err := policy.GrantAccess("bucket1", "user1", model.AccessRead)
+	require.NoError(t, err)
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(policy.String(), nil).Twice()
+	buckets.On("DeletePolicy", mock.Anything, "bucket1", mock.Anything).Return(nil).Once()
+
+	err = bucketpolicy.RevokeAccess(context.TODO(), buckets, "bucket1", "user1", nil)
+	require.NoError(t, err)
+}
+
+func testBucketPolicyRace(t *testing.T) {
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return("", nil).Once()
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(unrelated, nil).Times(3)
+	buckets.On("UpdatePolicy", mock.Anything, "bucket1", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", model.AccessRead, nil)
+	require.NoError(t, err)
+
+	// The statement written concurrently is preserved.
+	policy := written(t, buckets)
+	assert.NotNil(t, statement(policy, "admin"))
+	assert.NotNil(t, statement(policy, model.AccessRead.Sid("bucket1")))
+}
+
+func testBucketPolicyConflict(t *testing.T) {
+	var n int
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return(
+		func(context.Context, string, map[string]string) (string, error) {
+			n++
+
+			policy := &model.BucketPolicy{}
+			_, err := policy.GrantAccess("bucket1", "user", model.AccessLevels[n%len(model.AccessLevels)])
+
+			return policy.String(), err
+		})
+
+	err := bucketpolicy.GrantAccess(context.TODO(), buckets, "bucket1", "user1", model.AccessRead, nil)
+	require.ErrorIs(t, err, bucketpolicy.ErrConcurrentModification)
+	assert.True(t, model.IsConflict(err))
+	assert.Equal(t, 2*bucketpolicy.MaxAttempts, n)
+}
+
+func testBucketPolicyGetError(t *testing.T) {
+	notFound := model.Error{Description: "bucket not found", Code: model.CodeResourceNotFound}
+
+	buckets := mocks.NewBucketsInterface(t)
+	buckets.On("GetPolicy", mock.Anything, "bucket1", mock.Anything).Return("", notFound).Once()
+
+	err := bucketpolicy.RevokeAccess(context.TODO(), buckets, "bucket1", "user1", nil)
+	require.True(t, errors.Is(err, notFound))
+}
@@ -41,6 +41,7 @@ var _ api.ClientSet = (*ClientSet)(nil)
 func NewClientSet(objs ...interface{}) *ClientSet {
 	var (
 		policy                      = make(map[string]string)
+		acls                        = make(map[string]model.BucketACL)
 		bucketList                  []model.Bucket
 		blobUsers                   []model.BlobUser
 		userSecrets                 []UserSecret
@@ -68,6 +69,8 @@ func NewClientSet(objs ...interface{}) *ClientSet {
 			bucketList = append(bucketList, *object)
 		case *BucketPolicy:
 			policy[fmt.Sprintf("%s/%s", object.BucketName, object.Namespace)] = object.Policy
+		case *model.BucketACL:
+			acls[fmt.Sprintf("%s/%s", object.Bucket, object.Namespace)] = *object
 		case *model.BlobUser:
 			blobUsers = append(blobUsers, *object)
 		case *UserSecret:
@@ -109,15 +112,19 @@ func NewClientSet(objs ...interface{}) *ClientSet {
 		}
 	}
 
+	tenants := &Tenants{
+		items: tenantList,
+	}
+
 	return &ClientSet{
 		buckets: &Buckets{
-			items:  bucketList,
-			policy: policy,
+			items:   bucketList,
+			policy:  policy,
+			acls:    acls,
+			tenants: tenants,
 		},
 		objectUser: NewObjectUsers(blobUsers, userSecrets, userInfoList),
-		tenants: &Tenants{
-			items: tenantList,
-		},
+		tenants:    tenants,
 		objectMt: &Objmt{
 			accountBillingInfoList:      accountBillingInfoList,
 			accountBillingSampleList:    accountBillingSampleList,
@@ -363,11 +370,21 @@ var _ api.TenantsInterface = (*Tenants)(nil) // interface guard
 
 // Create creates a tenant in an object store.
 func (t *Tenants) Create(_ context.Context, payload model.TenantCreate) (*model.Tenant, error) {
+	for _, tenant := range t.items {
+		if tenant.ID == payload.AccountID {
+			return nil, model.Error{
+				Description: "tenant already exists",
+				Details:     payload.AccountID,
+			}
+		}
+	}
+
 	newtenant := &model.Tenant{
 		ID:                payload.AccountID,
 		EncryptionEnabled: payload.EncryptionEnabled,
 		ComplianceEnabled: payload.ComplianceEnabled,
 		BucketBlockSize:   payload.BucketBlockSize,
+		Alias:             payload.Alias,
 	}
 	t.items = append(t.items, *newtenant)
 
@@ -406,6 +423,43 @@ func (t *Tenants) Update(_ context.Context, payload model.TenantUpdate, tenantID
 	}
 }
 
+// Apply implements the tenants API.
+func (t *Tenants) Apply(ctx context.Context, payload model.TenantCreate) (*model.Tenant, model.ApplyResult, error) {
+	live, err := t.Get(ctx, payload.AccountID, nil)
+	if model.IsNotFound(err) {
+		created, createErr := t.Create(ctx, payload)
+		if createErr == nil {
+			return created, model.ApplyCreated, nil
+		}
+
+		live, err = t.Get(ctx, payload.AccountID, nil)
+		if model.IsNotFound(err) {
+			return nil, "", createErr
+		}
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := payload.CheckImmutable(*live); err != nil {
+		return nil, "", err
+	}
+
+	update, changed := payload.Update(*live)
+	if !changed {
+		return live, model.ApplyUnchanged, nil
+	}
+
+	if err := t.Update(ctx, update, payload.AccountID); err != nil {
+		return nil, "", err
+	}
+
+	live, err = t.Get(ctx, payload.AccountID, nil)
+
+	return live, model.ApplyUpdated, err
+}
+
 // Get implements the tenants API.
 func (t *Tenants) Get(_ context.Context, id string, _ map[string]string) (*model.Tenant, error) {
 	for _, tenant := range t.items {
@@ -486,8 +540,10 @@ func (t *Tenants) DeleteQuota(_ context.Context, id string) error {
 
 // Buckets implements the buckets API.
 type Buckets struct {
-	items  []model.Bucket
-	policy map[string]string
+	items   []model.Bucket
+	policy  map[string]string
+	acls    map[string]model.BucketACL
+	tenants *Tenants
 }
 
 var _ api.BucketsInterface = (*Buckets)(nil) // interface guard
@@ -538,6 +594,25 @@ func (b *Buckets) GetPolicy(_ context.Context, bucketName string, params map[str
 	return "", nil
 }
 
+// GetPolicyDocument implements the buckets API.
+func (b *Buckets) GetPolicyDocument(ctx context.Context, bucketName string, params map[string]string) (*model.BucketPolicy, error) {
+	policy, err := b.GetPolicy(ctx, bucketName, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseBucketPolicy(policy)
+}
+
+// UpdatePolicyDocument implements the buckets API.
+func (b *Buckets) UpdatePolicyDocument(ctx context.Context, bucketName string, policy model.BucketPolicy, params map[string]string) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	return b.UpdatePolicy(ctx, bucketName, policy.String(), params)
+}
+
 // DeletePolicy implements the buckets API.
 func (b *Buckets) DeletePolicy(_ context.Context, bucketName string, params map[string]string) error {
 	// this is not path, it is used to quickly distinguish which function must fail
@@ -592,6 +667,10 @@ func (b *Buckets) UpdatePolicy(_ context.Context, bucketName string, policy stri
 
 // Create implements the buckets API.
 func (b *Buckets) Create(_ context.Context, createParams model.Bucket) (*model.Bucket, error) {
+	if err := createParams.SearchMetadata.ValidateSearchMetadata(); err != nil {
+		return nil, err
+	}
+
 	// This piece of code verifies if the incoming request is for forcing an unexpected error.
 	if strings.Contains(createParams.Name, "FORCEFAIL") {
 		return &createParams, model.Error{
@@ -637,6 +716,146 @@ func (b *Buckets) Delete(_ context.Context, name string, namespace string, _ boo
 	}
 }
 
+// Apply implements the buckets API.
+func (b *Buckets) Apply(ctx context.Context, bucket model.Bucket) (*model.Bucket, model.ApplyResult, error) {
+	live, err := b.Get(ctx, bucket.Name, map[string]string{"namespace": bucket.Namespace})
+	if model.IsNotFound(err) {
+		live, err = b.Create(ctx, bucket)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return live, model.ApplyCreated, nil
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := bucket.CheckImmutable(*live); err != nil {
+		return nil, "", err
+	}
+
+	result := model.ApplyUnchanged
+
+	if update, changed := bucket.QuotaUpdate(*live); changed {
+		if err := b.UpdateQuota(ctx, update); err != nil {
+			return nil, "", err
+		}
+
+		result = model.ApplyUpdated
+	}
+
+	if retention, changed := bucket.RetentionUpdate(*live); changed {
+		if err := b.UpdateRetention(ctx, live.Name, live.Namespace, retention); err != nil {
+			return nil, "", err
+		}
+
+		result = model.ApplyUpdated
+	}
+
+	if group, permissions, changed := bucket.DefaultGroupUpdate(*live); changed {
+		if err := b.UpdateDefaultGroup(ctx, live.Name, live.Namespace, group, permissions); err != nil {
+			return nil, "", err
+		}
+
+		result = model.ApplyUpdated
+	}
+
+	if result == model.ApplyUnchanged {
+		return live, result, nil
+	}
+
+	live, err = b.Get(ctx, bucket.Name, map[string]string{"namespace": bucket.Namespace})
+
+	return live, result, err
+}
+
+// DisableSearchMetadata implements the buckets API.
+func (b *Buckets) DisableSearchMetadata(_ context.Context, bucketName string, namespace string) error {
+	for i := 0; i < len(b.items); i++ {
+		if b.items[i].Name == bucketName && b.items[i].Namespace == namespace {
+			b.items[i].SearchMetadata.Enabled = false
+			b.items[i].SearchMetadata.Metadata = nil
+
+			return nil
+		}
+	}
+
+	return model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
+// GetACL implements the buckets API.
+func (b *Buckets) GetACL(_ context.Context, bucketName string, namespace string) (*model.BucketACL, error) {
+	for _, bucket := range b.items {
+		if bucket.Name == bucketName && bucket.Namespace == namespace {
+			acl, ok := b.acls[fmt.Sprintf("%s/%s", bucketName, namespace)]
+			if !ok {
+				acl = model.BucketACL{Bucket: bucketName, Namespace: namespace}
+			}
+
+			if acl.ACL.Owner == "" {
+				acl.ACL.Owner = bucket.Owner
+			}
+
+			return &acl, nil
+		}
+	}
+
+	return nil, model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
+// SetACL implements the buckets API.
+func (b *Buckets) SetACL(_ context.Context, acl model.BucketACL) error {
+	if err := acl.Validate(); err != nil {
+		return err
+	}
+
+	for _, bucket := range b.items {
+		if bucket.Name == acl.Bucket && bucket.Namespace == acl.Namespace {
+			if b.acls == nil {
+				b.acls = make(map[string]model.BucketACL)
+			}
+
+			b.acls[fmt.Sprintf("%s/%s", acl.Bucket, acl.Namespace)] = acl
+
+			return nil
+		}
+	}
+
+	return model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
+// UpdateDefaultGroup implements the buckets API.
+func (b *Buckets) UpdateDefaultGroup(_ context.Context, bucketName string, namespace string, group string, permissions model.DefaultGroupPermissions) error {
+	for i := 0; i < len(b.items); i++ {
+		if b.items[i].Name == bucketName && b.items[i].Namespace == namespace {
+			if err := model.CheckDefaultGroupUpdate(b.items[i], group); err != nil {
+				return err
+			}
+
+			b.items[i].DefaultGroup = group
+			b.items[i].SetDefaultGroupPermissions(permissions)
+
+			return nil
+		}
+	}
+
+	return model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
 // GetQuota gets the quota for the given bucket and namespace.
 func (b *Buckets) GetQuota(_ context.Context, bucketName string, _ string) (*model.BucketQuotaInfo, error) {
 	for _, bucket := range b.items {
@@ -698,6 +917,61 @@ func (b *Buckets) DeleteQuota(_ context.Context, bucketName string, _ string) er
 	}
 }
 
+// GetRetention gets the default retention and the min/max governor of the bucket.
+func (b *Buckets) GetRetention(_ context.Context, bucketName string, namespace string) (*model.BucketRetention, error) {
+	for _, bucket := range b.items {
+		if bucket.Name == bucketName && bucket.Namespace == namespace {
+			return &model.BucketRetention{
+				DefaultRetention: bucket.DefaultRetention,
+				Governor:         bucket.MinMaxGovenor,
+			}, nil
+		}
+	}
+
+	return nil, model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
+// UpdateRetention updates the default retention and the min/max governor of the bucket.
+func (b *Buckets) UpdateRetention(ctx context.Context, bucketName string, namespace string, retention model.BucketRetention) error {
+	current, err := b.GetRetention(ctx, bucketName, namespace)
+	if err != nil {
+		return err
+	}
+
+	if b.tenants == nil {
+		return model.Error{
+			Description: "tenant not found",
+			Code:        model.CodeResourceNotFound,
+		}
+	}
+
+	tenant, err := b.tenants.Get(ctx, namespace, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := model.CheckRetentionUpdate(*tenant, *current, retention); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(b.items); i++ {
+		if b.items[i].Name == bucketName && b.items[i].Namespace == namespace {
+			b.items[i].DefaultRetention = retention.DefaultRetention
+			b.items[i].MinMaxGovenor = retention.Governor
+
+			return nil
+		}
+	}
+
+	return model.Error{
+		Description: "not found",
+		Code:        model.CodeResourceNotFound,
+	}
+}
+
 // Objmt is a fake (mocked) implementation of the Objmt interface.
 type Objmt struct {
 	accountBillingInfoList      *model.AccountBillingInfoList
@@ -858,6 +1132,15 @@ func (ap *AlertPolicies) List(_ context.Context, _ map[string]string) (*model.Al
 
 // Create implements the AlertPolicy API.
 func (ap *AlertPolicies) Create(_ context.Context, payload model.AlertPolicy) (*model.AlertPolicy, error) {
+	for _, alertpolicy := range ap.items {
+		if alertpolicy.PolicyName == payload.PolicyName {
+			return nil, model.Error{
+				Description: "alert policy already exists",
+				Details:     payload.PolicyName,
+			}
+		}
+	}
+
 	newAlertPolicy := &model.AlertPolicy{
 		PolicyName:           payload.PolicyName,
 		MetricType:           payload.MetricType,
@@ -910,6 +1193,7 @@ func (ap *AlertPolicies) Update(_ context.Context, payload model.AlertPolicy, po
 			ap.items[i].Statistic = payload.Statistic
 			ap.items[i].Operator = payload.Operator
 			ap.items[i].Condition = payload.Condition
+			alertpolicy = ap.items[i]
 
 			return &alertpolicy, nil
 		}
@@ -921,6 +1205,38 @@ func (ap *AlertPolicies) Update(_ context.Context, payload model.AlertPolicy, po
 	}
 }
 
+// Apply implements the AlertPolicy API.
+func (ap *AlertPolicies) Apply(ctx context.Context, payload model.AlertPolicy) (*model.AlertPolicy, model.ApplyResult, error) {
+	live, err := ap.Get(ctx, payload.PolicyName)
+	if model.IsNotFound(err) {
+		created, createErr := ap.Create(ctx, payload)
+		if createErr == nil {
+			return created, model.ApplyCreated, nil
+		}
+
+		live, err = ap.Get(ctx, payload.PolicyName)
+		if model.IsNotFound(err) {
+			return nil, "", createErr
+		}
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	merged, changed := payload.Merge(*live)
+	if !changed {
+		return live, model.ApplyUnchanged, nil
+	}
+
+	updated, err := ap.Update(ctx, merged, payload.PolicyName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updated, model.ApplyUpdated, nil
+}
+
 // Status implements the Status API.
 type Status struct {
 	RebuildInfo *model.RebuildInfo
@@ -0,0 +1,147 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/fake"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+func TestBuckets(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"ApplyDrift":     testBucketsApplyDrift,
+		"ApplyImmutable": testBucketsApplyImmutable,
+		"Retention":      testBucketsRetention,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testBucketsApplyDrift(t *testing.T) {
+	clientset := fake.NewClientSet(
+		&model.Tenant{ID: "ns1"},
+		&model.Bucket{Name: "bucket1", Namespace: "ns1", FSEnabled: true, BlockSize: 10},
+	)
+
+	desired := model.Bucket{Name: "bucket1", Namespace: "ns1", DefaultRetention: 100, DefaultGroup: "group1"}
+	desired.DefaultGroupFileReadPermission = true
+
+	bucket, result, err := clientset.Buckets().Apply(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUpdated, result)
+	assert.Equal(t, int64(100), bucket.DefaultRetention)
+	assert.Equal(t, "group1", bucket.DefaultGroup)
+	assert.True(t, bucket.DefaultGroupFileReadPermission)
+	assert.Equal(t, int64(10), bucket.BlockSize)
+
+	_, result, err = clientset.Buckets().Apply(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUnchanged, result)
+}
+
+func testBucketsApplyImmutable(t *testing.T) {
+	clientset := fake.NewClientSet(
+		&model.Tenant{ID: "ns1"},
+		&model.Bucket{Name: "bucket1", Namespace: "ns1", Owner: "user1", BlockSize: 10},
+	)
+
+	_, _, err := clientset.Buckets().Apply(context.TODO(), model.Bucket{
+		Name:              "bucket1",
+		Namespace:         "ns1",
+		Owner:             "user2",
+		EncryptionEnabled: true,
+		BlockSize:         20,
+	})
+	require.ErrorIs(t, err, model.ErrImmutableField)
+	assert.ErrorContains(t, err, "is_encryption_enabled, owner")
+
+	// Nothing is updated when the specification cannot be applied.
+	bucket, err := clientset.Buckets().Get(context.TODO(), "bucket1", map[string]string{"namespace": "ns1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), bucket.BlockSize)
+}
+
+func testBucketsRetention(t *testing.T) {
+	clientset := fake.NewClientSet(
+		&model.Tenant{ID: "ns1"},
+		&model.Tenant{ID: "ns2"},
+		&model.Bucket{Name: "bucket1", Namespace: "ns1", DefaultRetention: 10},
+		&model.Bucket{Name: "bucket1", Namespace: "ns2", DefaultRetention: 20},
+	)
+
+	retention, err := clientset.Buckets().GetRetention(context.TODO(), "bucket1", "ns2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), retention.DefaultRetention)
+
+	err = clientset.Buckets().UpdateRetention(context.TODO(), "bucket1", "ns2", model.BucketRetention{DefaultRetention: 30})
+	require.NoError(t, err)
+
+	// The bucket with the same name in another namespace is not updated.
+	retention, err = clientset.Buckets().GetRetention(context.TODO(), "bucket1", "ns1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), retention.DefaultRetention)
+
+	_, err = clientset.Buckets().GetRetention(context.TODO(), "bucket1", "ns3")
+	assert.True(t, model.IsNotFound(err))
+
+	err = clientset.Buckets().UpdateRetention(context.TODO(), "bucket2", "ns1", model.BucketRetention{DefaultRetention: 30})
+	assert.True(t, model.IsNotFound(err))
+}
+
+func TestTenants(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"ApplyImmutable": testTenantsApplyImmutable,
+		"CreateExisting": testTenantsCreateExisting,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testTenantsApplyImmutable(t *testing.T) {
+	clientset := fake.NewClientSet(&model.Tenant{ID: "ns1", Alias: "alias1"})
+
+	_, _, err := clientset.Tenants().Apply(context.TODO(), model.TenantCreate{
+		AccountID:         "ns1",
+		Alias:             "alias2",
+		EncryptionEnabled: true,
+	})
+	require.ErrorIs(t, err, model.ErrImmutableField)
+	assert.ErrorContains(t, err, "is_encryption_enabled")
+
+	// Nothing is updated when the specification cannot be applied.
+	tenant, err := clientset.Tenants().Get(context.TODO(), "ns1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "alias1", tenant.Alias)
+}
+
+func testTenantsCreateExisting(t *testing.T) {
+	clientset := fake.NewClientSet(&model.Tenant{ID: "ns1"})
+
+	// Like the management API, the fake returns an error without a known code.
+	_, err := clientset.Tenants().Create(context.TODO(), model.TenantCreate{AccountID: "ns1"})
+	require.ErrorAs(t, err, &model.Error{})
+	assert.False(t, model.IsAlreadyExists(err))
+
+	_, err = clientset.AlertPolicies().Create(context.TODO(), model.AlertPolicy{PolicyName: "policy1"})
+	require.NoError(t, err)
+
+	_, err = clientset.AlertPolicies().Create(context.TODO(), model.AlertPolicy{PolicyName: "policy1"})
+	require.ErrorAs(t, err, &model.Error{})
+	assert.False(t, model.IsAlreadyExists(err))
+}
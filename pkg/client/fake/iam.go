@@ -0,0 +1,2303 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+
+	objscaleIAM "github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+// Limits of the fake IAM API.
+const (
+	// IAMMaxAccessKeys is the number of access keys a user may have.
+	IAMMaxAccessKeys = 2
+
+	// IAMMaxItems is the page size of lists if MaxItems is not set.
+	IAMMaxItems = 100
+
+	// IAMPolicyVersion is the version of managed policy documents.
+	IAMPolicyVersion = "v1"
+)
+
+// iamRequestID is the request ID of the errors of the fake IAM API.
+const iamRequestID = "00000000-0000-0000-0000-000000000000"
+
+// IAM is an in-memory fake of the ObjectScale IAM API, covering users, groups,
+// roles, managed and inline policies, attachments and access keys. Entities
+// are scoped per account: the account ID set in the request context with
+// iam.WithAccountID, or the account of the fake. Errors are AWS request
+// failures with IAM error codes, e.g. NoSuchEntity, that iam.TranslateError
+// understands. Operations on the calling user, without a user name, are not
+// supported, and methods that are not implemented panic.
+type IAM struct {
+	iamiface.IAMAPI
+
+	state     *iamState
+	accountID string
+}
+
+var _ iamiface.IAMAPI = (*IAM)(nil) // interface guard
+
+// iamState is the state of the fake IAM API, shared by all accounts.
+type iamState struct {
+	mu       sync.Mutex
+	accounts map[string]*iamAccount
+	ids      int
+}
+
+// iamAccount are the IAM entities of an account.
+type iamAccount struct {
+	id       string
+	users    map[string]*iamUser
+	groups   map[string]*iamGroup
+	roles    map[string]*iamRole
+	policies map[string]*iamPolicy
+}
+
+// iamPrincipal are the policies of a user, group or role.
+type iamPrincipal struct {
+	attached []string
+	inline   map[string]string
+}
+
+type iamUser struct {
+	iamPrincipal
+	user iam.User
+	keys []*iam.AccessKey
+}
+
+type iamGroup struct {
+	iamPrincipal
+	group iam.Group
+	users []string
+}
+
+type iamRole struct {
+	iamPrincipal
+	role iam.Role
+}
+
+type iamPolicy struct {
+	policy   iam.Policy
+	document string
+}
+
+// NewIAM returns an empty fake IAM API acting on the account, unless another
+// is set in the request context.
+func NewIAM(accountID string) *IAM {
+	return &IAM{
+		state:     &iamState{accounts: make(map[string]*iamAccount)},
+		accountID: accountID,
+	}
+}
+
+// WithAccount returns a fake IAM API sharing the state of f, acting on another
+// account.
+func (f *IAM) WithAccount(accountID string) *IAM {
+	return &IAM{state: f.state, accountID: accountID}
+}
+
+// iamError returns an IAM API error.
+func iamError(code string, status int, format string, args ...interface{}) error {
+	return awserr.NewRequestFailure(awserr.New(code, fmt.Sprintf(format, args...), nil), status, iamRequestID)
+}
+
+func noSuchEntity(format string, args ...interface{}) error {
+	return iamError(iam.ErrCodeNoSuchEntityException, http.StatusNotFound, format, args...)
+}
+
+func entityAlreadyExists(format string, args ...interface{}) error {
+	return iamError(iam.ErrCodeEntityAlreadyExistsException, http.StatusConflict, format, args...)
+}
+
+func deleteConflict(format string, args ...interface{}) error {
+	return iamError(iam.ErrCodeDeleteConflictException, http.StatusConflict, format, args...)
+}
+
+func invalidInput(format string, args ...interface{}) error {
+	return iamError(iam.ErrCodeInvalidInputException, http.StatusBadRequest, format, args...)
+}
+
+// lock locks the state and returns the account of the request. The state
+// must be unlocked by the caller, even on error.
+func (f *IAM) lock(ctx context.Context) (*iamAccount, error) {
+	f.state.mu.Lock()
+
+	accountID := f.accountID
+	if id, ok := objscaleIAM.AccountIDFromContext(ctx); ok {
+		accountID = id
+	}
+
+	if accountID == "" {
+		return nil, invalidInput("Account ID is required.")
+	}
+
+	account, ok := f.state.accounts[accountID]
+	if !ok {
+		account = &iamAccount{
+			id:       accountID,
+			users:    make(map[string]*iamUser),
+			groups:   make(map[string]*iamGroup),
+			roles:    make(map[string]*iamRole),
+			policies: make(map[string]*iamPolicy),
+		}
+		f.state.accounts[accountID] = account
+	}
+
+	return account, nil
+}
+
+func (f *IAM) unlock() {
+	f.state.mu.Unlock()
+}
+
+// newID returns a unique ID with the prefix, in the format of IAM unique IDs.
+func (f *IAM) newID(prefix string) string {
+	f.state.ids++
+
+	return fmt.Sprintf("%s%017X", prefix, f.state.ids)
+}
+
+// arn returns the ARN of an entity of the account.
+func (a *iamAccount) arn(kind, path, name string) string {
+	return fmt.Sprintf("urn:ecs:iam::%s:%s%s%s", a.id, kind, path, name)
+}
+
+// entityPath returns the path of a new entity, "/" by default.
+func entityPath(path *string) (string, error) {
+	p := aws.StringValue(path)
+	if p == "" {
+		return "/", nil
+	}
+
+	if !strings.HasPrefix(p, "/") || !strings.HasSuffix(p, "/") {
+		return "", invalidInput("The specified value for path is invalid. It must begin and end with / and contain only alphanumeric characters and/or / characters.")
+	}
+
+	return p, nil
+}
+
+// checkDocument checks that the policy document is JSON.
+func checkDocument(document *string) error {
+	if !json.Valid([]byte(aws.StringValue(document))) {
+		return iamError(iam.ErrCodeMalformedPolicyDocumentException, http.StatusBadRequest, "Syntax errors in policy.")
+	}
+
+	return nil
+}
+
+// encodeDocument encodes a policy document as returned by the IAM API.
+func encodeDocument(document string) *string {
+	return aws.String(url.QueryEscape(document))
+}
+
+func (a *iamAccount) user(name *string) (*iamUser, error) {
+	u, ok := a.users[aws.StringValue(name)]
+	if !ok {
+		return nil, noSuchEntity("The user with name %s cannot be found.", aws.StringValue(name))
+	}
+
+	return u, nil
+}
+
+func (a *iamAccount) group(name *string) (*iamGroup, error) {
+	g, ok := a.groups[aws.StringValue(name)]
+	if !ok {
+		return nil, noSuchEntity("The group with name %s cannot be found.", aws.StringValue(name))
+	}
+
+	return g, nil
+}
+
+func (a *iamAccount) role(name *string) (*iamRole, error) {
+	r, ok := a.roles[aws.StringValue(name)]
+	if !ok {
+		return nil, noSuchEntity("The role with name %s cannot be found.", aws.StringValue(name))
+	}
+
+	return r, nil
+}
+
+func (a *iamAccount) policy(arn *string) (*iamPolicy, error) {
+	p, ok := a.policies[aws.StringValue(arn)]
+	if !ok {
+		return nil, noSuchEntity("Policy %s does not exist or is not attachable.", aws.StringValue(arn))
+	}
+
+	return p, nil
+}
+
+// accessKey returns the access key of the user.
+func (a *iamAccount) accessKey(userName, accessKeyID *string) (*iam.AccessKey, error) {
+	u, err := a.user(userName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range u.keys {
+		if aws.StringValue(key.AccessKeyId) == aws.StringValue(accessKeyID) {
+			return key, nil
+		}
+	}
+
+	return nil, noSuchEntity("The Access Key with id %s cannot be found.", aws.StringValue(accessKeyID))
+}
+
+// sortedValues returns the values of the map sorted by key.
+func sortedValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	values := make([]T, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+
+	return values
+}
+
+// paginate returns the page of items starting at the marker, the marker of
+// the next page and whether there are more pages.
+func paginate[T any](items []T, marker *string, maxItems *int64) ([]T, *string, bool, error) {
+	start := 0
+
+	if marker != nil {
+		n, err := strconv.Atoi(aws.StringValue(marker))
+		if err != nil || n < 0 || n > len(items) {
+			return nil, nil, false, invalidInput("Invalid Marker.")
+		}
+
+		start = n
+	}
+
+	size := IAMMaxItems
+	if maxItems != nil {
+		size = int(aws.Int64Value(maxItems))
+	}
+
+	if size <= 0 {
+		return nil, nil, false, invalidInput("MaxItems must be positive.")
+	}
+
+	end := min(start+size, len(items))
+	if end == len(items) {
+		return items[start:end], nil, false, nil
+	}
+
+	return items[start:end], aws.String(strconv.Itoa(end)), true, nil
+}
+
+// eachPage calls list with the marker of the previous page, starting at
+// marker, until the last page or until fn returns false.
+func eachPage[O any](marker *string, list func(marker *string) (*O, error),
+	next func(*O) (*string, bool), fn func(*O, bool) bool,
+) error {
+	for {
+		out, err := list(marker)
+		if err != nil {
+			return err
+		}
+
+		nextMarker, truncated := next(out)
+		if !fn(out, !truncated) || !truncated {
+			return nil
+		}
+
+		marker = nextMarker
+	}
+}
+
+// attach attaches the managed policy to the principal. Attaching a policy
+// twice has no effect.
+func (a *iamAccount) attach(p *iamPrincipal, arn *string) error {
+	policy, err := a.policy(arn)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(p.attached, aws.StringValue(arn)) {
+		return nil
+	}
+
+	p.attached = append(p.attached, aws.StringValue(arn))
+	policy.policy.AttachmentCount = aws.Int64(aws.Int64Value(policy.policy.AttachmentCount) + 1)
+
+	return nil
+}
+
+// detach detaches the managed policy from the principal.
+func (a *iamAccount) detach(p *iamPrincipal, arn *string) error {
+	i := slices.Index(p.attached, aws.StringValue(arn))
+	if i < 0 {
+		return noSuchEntity("Policy %s was not found.", aws.StringValue(arn))
+	}
+
+	p.attached = slices.Delete(p.attached, i, i+1)
+
+	if policy, ok := a.policies[aws.StringValue(arn)]; ok {
+		policy.policy.AttachmentCount = aws.Int64(aws.Int64Value(policy.policy.AttachmentCount) - 1)
+	}
+
+	return nil
+}
+
+// attachedPolicies returns the managed policies attached to the principal
+// whose path starts with pathPrefix.
+func (a *iamAccount) attachedPolicies(p *iamPrincipal, pathPrefix *string) []*iam.AttachedPolicy {
+	var attached []*iam.AttachedPolicy
+
+	for _, arn := range p.attached {
+		policy := a.policies[arn]
+		if !strings.HasPrefix(aws.StringValue(policy.policy.Path), aws.StringValue(pathPrefix)) {
+			continue
+		}
+
+		attached = append(attached, &iam.AttachedPolicy{PolicyArn: aws.String(arn), PolicyName: policy.policy.PolicyName})
+	}
+
+	return attached
+}
+
+// putPolicy adds or replaces an inline policy of the principal.
+func (p *iamPrincipal) putPolicy(name, document *string) error {
+	if err := checkDocument(document); err != nil {
+		return err
+	}
+
+	if p.inline == nil {
+		p.inline = make(map[string]string)
+	}
+
+	p.inline[aws.StringValue(name)] = aws.StringValue(document)
+
+	return nil
+}
+
+// getPolicy returns the encoded document of an inline policy of the principal.
+func (p *iamPrincipal) getPolicy(kind string, name *string) (*string, error) {
+	document, ok := p.inline[aws.StringValue(name)]
+	if !ok {
+		return nil, noSuchEntity("The %s policy with name %s cannot be found.", kind, aws.StringValue(name))
+	}
+
+	return encodeDocument(document), nil
+}
+
+// deletePolicy deletes an inline policy of the principal.
+func (p *iamPrincipal) deletePolicy(kind string, name *string) error {
+	if _, ok := p.inline[aws.StringValue(name)]; !ok {
+		return noSuchEntity("The %s policy with name %s cannot be found.", kind, aws.StringValue(name))
+	}
+
+	delete(p.inline, aws.StringValue(name))
+
+	return nil
+}
+
+// policyNames returns the sorted names of the inline policies of the principal.
+func (p *iamPrincipal) policyNames() []*string {
+	names := make([]*string, 0, len(p.inline))
+	for name := range p.inline {
+		names = append(names, aws.String(name))
+	}
+
+	slices.SortFunc(names, func(a, b *string) int { return strings.Compare(*a, *b) })
+
+	return names
+}
+
+// checkDelete returns an error if the principal still has policies.
+func (p *iamPrincipal) checkDelete() error {
+	if len(p.inline) != 0 {
+		return deleteConflict("Cannot delete entity, must delete policies first.")
+	}
+
+	if len(p.attached) != 0 {
+		return deleteConflict("Cannot delete entity, must detach all policies first.")
+	}
+
+	return nil
+}
+
+// CreateUser implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateUser(in *iam.CreateUserInput) (*iam.CreateUserOutput, error) {
+	return f.CreateUserWithContext(aws.BackgroundContext(), in)
+}
+
+// CreateUserWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateUserWithContext(ctx aws.Context, in *iam.CreateUserInput, _ ...request.Option) (*iam.CreateUserOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(in.UserName)
+	if _, ok := a.users[name]; ok {
+		return nil, entityAlreadyExists("User with name %s already exists.", name)
+	}
+
+	path, err := entityPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &iamUser{user: iam.User{
+		Arn:        aws.String(a.arn("user", path, name)),
+		CreateDate: aws.Time(time.Now().UTC()),
+		Path:       aws.String(path),
+		Tags:       in.Tags,
+		UserId:     aws.String(f.newID("AIDA")),
+		UserName:   aws.String(name),
+	}}
+	a.users[name] = u
+
+	user := u.user
+
+	return &iam.CreateUserOutput{User: &user}, nil
+}
+
+// GetUser implements the iamiface.IAMAPI interface.
+func (f *IAM) GetUser(in *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	return f.GetUserWithContext(aws.BackgroundContext(), in)
+}
+
+// GetUserWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) GetUserWithContext(ctx aws.Context, in *iam.GetUserInput, _ ...request.Option) (*iam.GetUserOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	user := u.user
+
+	return &iam.GetUserOutput{User: &user}, nil
+}
+
+// DeleteUser implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteUser(in *iam.DeleteUserInput) (*iam.DeleteUserOutput, error) {
+	return f.DeleteUserWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteUserWithContext implements the iamiface.IAMAPI interface. Users with
+// access keys, policies or group memberships cannot be deleted.
+func (f *IAM) DeleteUserWithContext(ctx aws.Context, in *iam.DeleteUserInput, _ ...request.Option) (*iam.DeleteUserOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(u.keys) != 0 {
+		return nil, deleteConflict("Cannot delete entity, must delete access keys first.")
+	}
+
+	if err := u.checkDelete(); err != nil {
+		return nil, err
+	}
+
+	for _, g := range a.groups {
+		if slices.Contains(g.users, aws.StringValue(in.UserName)) {
+			return nil, deleteConflict("Cannot delete entity, must remove users from group first.")
+		}
+	}
+
+	delete(a.users, aws.StringValue(in.UserName))
+
+	return &iam.DeleteUserOutput{}, nil
+}
+
+// ListUsers implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUsers(in *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+	return f.ListUsersWithContext(aws.BackgroundContext(), in)
+}
+
+// ListUsersWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUsersWithContext(ctx aws.Context, in *iam.ListUsersInput, _ ...request.Option) (*iam.ListUsersOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*iam.User
+
+	for _, u := range sortedValues(a.users) {
+		if strings.HasPrefix(aws.StringValue(u.user.Path), aws.StringValue(in.PathPrefix)) {
+			user := u.user
+			users = append(users, &user)
+		}
+	}
+
+	page, marker, truncated, err := paginate(users, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListUsersOutput{Users: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListUsersPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUsersPages(in *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error {
+	return f.ListUsersPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListUsersPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUsersPagesWithContext(ctx aws.Context, in *iam.ListUsersInput,
+	fn func(*iam.ListUsersOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListUsersOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListUsersWithContext(ctx, &input)
+	}, func(out *iam.ListUsersOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// CreateGroup implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateGroup(in *iam.CreateGroupInput) (*iam.CreateGroupOutput, error) {
+	return f.CreateGroupWithContext(aws.BackgroundContext(), in)
+}
+
+// CreateGroupWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateGroupWithContext(ctx aws.Context, in *iam.CreateGroupInput, _ ...request.Option) (*iam.CreateGroupOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(in.GroupName)
+	if _, ok := a.groups[name]; ok {
+		return nil, entityAlreadyExists("Group with name %s already exists.", name)
+	}
+
+	path, err := entityPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &iamGroup{group: iam.Group{
+		Arn:        aws.String(a.arn("group", path, name)),
+		CreateDate: aws.Time(time.Now().UTC()),
+		GroupId:    aws.String(f.newID("AGPA")),
+		GroupName:  aws.String(name),
+		Path:       aws.String(path),
+	}}
+	a.groups[name] = g
+
+	group := g.group
+
+	return &iam.CreateGroupOutput{Group: &group}, nil
+}
+
+// GetGroup implements the iamiface.IAMAPI interface.
+func (f *IAM) GetGroup(in *iam.GetGroupInput) (*iam.GetGroupOutput, error) {
+	return f.GetGroupWithContext(aws.BackgroundContext(), in)
+}
+
+// GetGroupWithContext implements the iamiface.IAMAPI interface. The users of
+// the group are paginated.
+func (f *IAM) GetGroupWithContext(ctx aws.Context, in *iam.GetGroupInput, _ ...request.Option) (*iam.GetGroupOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*iam.User, 0, len(g.users))
+
+	for _, name := range g.users {
+		user := a.users[name].user
+		users = append(users, &user)
+	}
+
+	page, marker, truncated, err := paginate(users, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	group := g.group
+
+	return &iam.GetGroupOutput{Group: &group, Users: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// GetGroupPages implements the iamiface.IAMAPI interface.
+func (f *IAM) GetGroupPages(in *iam.GetGroupInput, fn func(*iam.GetGroupOutput, bool) bool) error {
+	return f.GetGroupPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// GetGroupPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) GetGroupPagesWithContext(ctx aws.Context, in *iam.GetGroupInput,
+	fn func(*iam.GetGroupOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.GetGroupOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.GetGroupWithContext(ctx, &input)
+	}, func(out *iam.GetGroupOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// DeleteGroup implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteGroup(in *iam.DeleteGroupInput) (*iam.DeleteGroupOutput, error) {
+	return f.DeleteGroupWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteGroupWithContext implements the iamiface.IAMAPI interface. Groups with
+// users or policies cannot be deleted.
+func (f *IAM) DeleteGroupWithContext(ctx aws.Context, in *iam.DeleteGroupInput, _ ...request.Option) (*iam.DeleteGroupOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(g.users) != 0 {
+		return nil, deleteConflict("Cannot delete entity, must remove users from group first.")
+	}
+
+	if err := g.checkDelete(); err != nil {
+		return nil, err
+	}
+
+	delete(a.groups, aws.StringValue(in.GroupName))
+
+	return &iam.DeleteGroupOutput{}, nil
+}
+
+// ListGroups implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroups(in *iam.ListGroupsInput) (*iam.ListGroupsOutput, error) {
+	return f.ListGroupsWithContext(aws.BackgroundContext(), in)
+}
+
+// ListGroupsWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsWithContext(ctx aws.Context, in *iam.ListGroupsInput, _ ...request.Option) (*iam.ListGroupsOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []*iam.Group
+
+	for _, g := range sortedValues(a.groups) {
+		if strings.HasPrefix(aws.StringValue(g.group.Path), aws.StringValue(in.PathPrefix)) {
+			group := g.group
+			groups = append(groups, &group)
+		}
+	}
+
+	page, marker, truncated, err := paginate(groups, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListGroupsOutput{Groups: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListGroupsPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsPages(in *iam.ListGroupsInput, fn func(*iam.ListGroupsOutput, bool) bool) error {
+	return f.ListGroupsPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListGroupsPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsPagesWithContext(ctx aws.Context, in *iam.ListGroupsInput,
+	fn func(*iam.ListGroupsOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListGroupsOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListGroupsWithContext(ctx, &input)
+	}, func(out *iam.ListGroupsOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// AddUserToGroup implements the iamiface.IAMAPI interface.
+func (f *IAM) AddUserToGroup(in *iam.AddUserToGroupInput) (*iam.AddUserToGroupOutput, error) {
+	return f.AddUserToGroupWithContext(aws.BackgroundContext(), in)
+}
+
+// AddUserToGroupWithContext implements the iamiface.IAMAPI interface. Adding
+// a member of the group has no effect.
+func (f *IAM) AddUserToGroupWithContext(ctx aws.Context, in *iam.AddUserToGroupInput, _ ...request.Option) (*iam.AddUserToGroupOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.user(in.UserName); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(g.users, aws.StringValue(in.UserName)) {
+		g.users = append(g.users, aws.StringValue(in.UserName))
+	}
+
+	return &iam.AddUserToGroupOutput{}, nil
+}
+
+// RemoveUserFromGroup implements the iamiface.IAMAPI interface.
+func (f *IAM) RemoveUserFromGroup(in *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error) {
+	return f.RemoveUserFromGroupWithContext(aws.BackgroundContext(), in)
+}
+
+// RemoveUserFromGroupWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) RemoveUserFromGroupWithContext(ctx aws.Context, in *iam.RemoveUserFromGroupInput,
+	_ ...request.Option,
+) (*iam.RemoveUserFromGroupOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.Index(g.users, aws.StringValue(in.UserName))
+	if i < 0 {
+		return nil, noSuchEntity("The user with name %s cannot be found.", aws.StringValue(in.UserName))
+	}
+
+	g.users = slices.Delete(g.users, i, i+1)
+
+	return &iam.RemoveUserFromGroupOutput{}, nil
+}
+
+// ListGroupsForUser implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsForUser(in *iam.ListGroupsForUserInput) (*iam.ListGroupsForUserOutput, error) {
+	return f.ListGroupsForUserWithContext(aws.BackgroundContext(), in)
+}
+
+// ListGroupsForUserWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsForUserWithContext(ctx aws.Context, in *iam.ListGroupsForUserInput,
+	_ ...request.Option,
+) (*iam.ListGroupsForUserOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.user(in.UserName); err != nil {
+		return nil, err
+	}
+
+	var groups []*iam.Group
+
+	for _, g := range sortedValues(a.groups) {
+		if slices.Contains(g.users, aws.StringValue(in.UserName)) {
+			group := g.group
+			groups = append(groups, &group)
+		}
+	}
+
+	page, marker, truncated, err := paginate(groups, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListGroupsForUserOutput{Groups: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListGroupsForUserPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsForUserPages(in *iam.ListGroupsForUserInput, fn func(*iam.ListGroupsForUserOutput, bool) bool) error {
+	return f.ListGroupsForUserPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListGroupsForUserPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupsForUserPagesWithContext(ctx aws.Context, in *iam.ListGroupsForUserInput,
+	fn func(*iam.ListGroupsForUserOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListGroupsForUserOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListGroupsForUserWithContext(ctx, &input)
+	}, func(out *iam.ListGroupsForUserOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// CreateRole implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateRole(in *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+	return f.CreateRoleWithContext(aws.BackgroundContext(), in)
+}
+
+// CreateRoleWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateRoleWithContext(ctx aws.Context, in *iam.CreateRoleInput, _ ...request.Option) (*iam.CreateRoleOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(in.RoleName)
+	if _, ok := a.roles[name]; ok {
+		return nil, entityAlreadyExists("Role with name %s already exists.", name)
+	}
+
+	path, err := entityPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkDocument(in.AssumeRolePolicyDocument); err != nil {
+		return nil, err
+	}
+
+	maxSessionDuration := in.MaxSessionDuration
+	if maxSessionDuration == nil {
+		maxSessionDuration = aws.Int64(int64(time.Hour / time.Second))
+	}
+
+	r := &iamRole{role: iam.Role{
+		Arn:                      aws.String(a.arn("role", path, name)),
+		AssumeRolePolicyDocument: encodeDocument(aws.StringValue(in.AssumeRolePolicyDocument)),
+		CreateDate:               aws.Time(time.Now().UTC()),
+		Description:              in.Description,
+		MaxSessionDuration:       maxSessionDuration,
+		Path:                     aws.String(path),
+		RoleId:                   aws.String(f.newID("AROA")),
+		RoleName:                 aws.String(name),
+		Tags:                     in.Tags,
+	}}
+	a.roles[name] = r
+
+	role := r.role
+
+	return &iam.CreateRoleOutput{Role: &role}, nil
+}
+
+// GetRole implements the iamiface.IAMAPI interface.
+func (f *IAM) GetRole(in *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	return f.GetRoleWithContext(aws.BackgroundContext(), in)
+}
+
+// GetRoleWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) GetRoleWithContext(ctx aws.Context, in *iam.GetRoleInput, _ ...request.Option) (*iam.GetRoleOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	role := r.role
+
+	return &iam.GetRoleOutput{Role: &role}, nil
+}
+
+// DeleteRole implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteRole(in *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	return f.DeleteRoleWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteRoleWithContext implements the iamiface.IAMAPI interface. Roles with
+// policies cannot be deleted.
+func (f *IAM) DeleteRoleWithContext(ctx aws.Context, in *iam.DeleteRoleInput, _ ...request.Option) (*iam.DeleteRoleOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.checkDelete(); err != nil {
+		return nil, err
+	}
+
+	delete(a.roles, aws.StringValue(in.RoleName))
+
+	return &iam.DeleteRoleOutput{}, nil
+}
+
+// ListRoles implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRoles(in *iam.ListRolesInput) (*iam.ListRolesOutput, error) {
+	return f.ListRolesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListRolesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolesWithContext(ctx aws.Context, in *iam.ListRolesInput, _ ...request.Option) (*iam.ListRolesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []*iam.Role
+
+	for _, r := range sortedValues(a.roles) {
+		if strings.HasPrefix(aws.StringValue(r.role.Path), aws.StringValue(in.PathPrefix)) {
+			role := r.role
+			roles = append(roles, &role)
+		}
+	}
+
+	page, marker, truncated, err := paginate(roles, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListRolesOutput{Roles: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListRolesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolesPages(in *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+	return f.ListRolesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListRolesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolesPagesWithContext(ctx aws.Context, in *iam.ListRolesInput,
+	fn func(*iam.ListRolesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListRolesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListRolesWithContext(ctx, &input)
+	}, func(out *iam.ListRolesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// CreatePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) CreatePolicy(in *iam.CreatePolicyInput) (*iam.CreatePolicyOutput, error) {
+	return f.CreatePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// CreatePolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) CreatePolicyWithContext(ctx aws.Context, in *iam.CreatePolicyInput, _ ...request.Option) (*iam.CreatePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := entityPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.StringValue(in.PolicyName)
+	arn := a.arn("policy", path, name)
+
+	if _, ok := a.policies[arn]; ok {
+		return nil, entityAlreadyExists("A policy called %s already exists. Duplicate names are not allowed.", name)
+	}
+
+	if err := checkDocument(in.PolicyDocument); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	p := &iamPolicy{
+		policy: iam.Policy{
+			Arn:              aws.String(arn),
+			AttachmentCount:  aws.Int64(0),
+			CreateDate:       aws.Time(now),
+			DefaultVersionId: aws.String(IAMPolicyVersion),
+			Description:      in.Description,
+			IsAttachable:     aws.Bool(true),
+			Path:             aws.String(path),
+			PolicyId:         aws.String(f.newID("ANPA")),
+			PolicyName:       aws.String(name),
+			Tags:             in.Tags,
+			UpdateDate:       aws.Time(now),
+		},
+		document: aws.StringValue(in.PolicyDocument),
+	}
+	a.policies[arn] = p
+
+	policy := p.policy
+
+	return &iam.CreatePolicyOutput{Policy: &policy}, nil
+}
+
+// GetPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) GetPolicy(in *iam.GetPolicyInput) (*iam.GetPolicyOutput, error) {
+	return f.GetPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// GetPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) GetPolicyWithContext(ctx aws.Context, in *iam.GetPolicyInput, _ ...request.Option) (*iam.GetPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := a.policy(in.PolicyArn)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := p.policy
+
+	return &iam.GetPolicyOutput{Policy: &policy}, nil
+}
+
+// GetPolicyVersion implements the iamiface.IAMAPI interface.
+func (f *IAM) GetPolicyVersion(in *iam.GetPolicyVersionInput) (*iam.GetPolicyVersionOutput, error) {
+	return f.GetPolicyVersionWithContext(aws.BackgroundContext(), in)
+}
+
+// GetPolicyVersionWithContext implements the iamiface.IAMAPI interface.
+// Managed policies have a single version, IAMPolicyVersion.
+func (f *IAM) GetPolicyVersionWithContext(ctx aws.Context, in *iam.GetPolicyVersionInput,
+	_ ...request.Option,
+) (*iam.GetPolicyVersionOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := a.policy(in.PolicyArn)
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.StringValue(in.VersionId) != IAMPolicyVersion {
+		return nil, noSuchEntity("Policy %s version %s does not exist or is not attachable.",
+			aws.StringValue(in.PolicyArn), aws.StringValue(in.VersionId))
+	}
+
+	return &iam.GetPolicyVersionOutput{PolicyVersion: &iam.PolicyVersion{
+		CreateDate:       p.policy.CreateDate,
+		Document:         encodeDocument(p.document),
+		IsDefaultVersion: aws.Bool(true),
+		VersionId:        aws.String(IAMPolicyVersion),
+	}}, nil
+}
+
+// DeletePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DeletePolicy(in *iam.DeletePolicyInput) (*iam.DeletePolicyOutput, error) {
+	return f.DeletePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DeletePolicyWithContext implements the iamiface.IAMAPI interface. Attached
+// policies cannot be deleted.
+func (f *IAM) DeletePolicyWithContext(ctx aws.Context, in *iam.DeletePolicyInput, _ ...request.Option) (*iam.DeletePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := a.policy(in.PolicyArn)
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.Int64Value(p.policy.AttachmentCount) != 0 {
+		return nil, deleteConflict("Cannot delete a policy attached to entities.")
+	}
+
+	delete(a.policies, aws.StringValue(in.PolicyArn))
+
+	return &iam.DeletePolicyOutput{}, nil
+}
+
+// ListPolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListPolicies(in *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error) {
+	return f.ListPoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListPoliciesWithContext implements the iamiface.IAMAPI interface. All the
+// policies are in the Local scope.
+func (f *IAM) ListPoliciesWithContext(ctx aws.Context, in *iam.ListPoliciesInput, _ ...request.Option) (*iam.ListPoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*iam.Policy
+
+	if aws.StringValue(in.Scope) != iam.PolicyScopeTypeAws {
+		for _, p := range sortedValues(a.policies) {
+			if !strings.HasPrefix(aws.StringValue(p.policy.Path), aws.StringValue(in.PathPrefix)) {
+				continue
+			}
+
+			if aws.BoolValue(in.OnlyAttached) && aws.Int64Value(p.policy.AttachmentCount) == 0 {
+				continue
+			}
+
+			policy := p.policy
+			policies = append(policies, &policy)
+		}
+	}
+
+	page, marker, truncated, err := paginate(policies, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListPoliciesOutput{Policies: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListPoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListPoliciesPages(in *iam.ListPoliciesInput, fn func(*iam.ListPoliciesOutput, bool) bool) error {
+	return f.ListPoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListPoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListPoliciesPagesWithContext(ctx aws.Context, in *iam.ListPoliciesInput,
+	fn func(*iam.ListPoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListPoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListPoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListPoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// AttachUserPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachUserPolicy(in *iam.AttachUserPolicyInput) (*iam.AttachUserPolicyOutput, error) {
+	return f.AttachUserPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// AttachUserPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachUserPolicyWithContext(ctx aws.Context, in *iam.AttachUserPolicyInput,
+	_ ...request.Option,
+) (*iam.AttachUserPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.attach(&user.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.AttachUserPolicyOutput{}, nil
+}
+
+// DetachUserPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachUserPolicy(in *iam.DetachUserPolicyInput) (*iam.DetachUserPolicyOutput, error) {
+	return f.DetachUserPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DetachUserPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachUserPolicyWithContext(ctx aws.Context, in *iam.DetachUserPolicyInput,
+	_ ...request.Option,
+) (*iam.DetachUserPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.detach(&user.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.DetachUserPolicyOutput{}, nil
+}
+
+// ListAttachedUserPolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedUserPolicies(in *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error) {
+	return f.ListAttachedUserPoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListAttachedUserPoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedUserPoliciesWithContext(ctx aws.Context, in *iam.ListAttachedUserPoliciesInput,
+	_ ...request.Option,
+) (*iam.ListAttachedUserPoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(a.attachedPolicies(&user.iamPrincipal, in.PathPrefix), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListAttachedUserPoliciesOutput{AttachedPolicies: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListAttachedUserPoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedUserPoliciesPages(in *iam.ListAttachedUserPoliciesInput,
+	fn func(*iam.ListAttachedUserPoliciesOutput, bool) bool,
+) error {
+	return f.ListAttachedUserPoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListAttachedUserPoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedUserPoliciesPagesWithContext(ctx aws.Context, in *iam.ListAttachedUserPoliciesInput,
+	fn func(*iam.ListAttachedUserPoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListAttachedUserPoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListAttachedUserPoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListAttachedUserPoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// PutUserPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) PutUserPolicy(in *iam.PutUserPolicyInput) (*iam.PutUserPolicyOutput, error) {
+	return f.PutUserPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// PutUserPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) PutUserPolicyWithContext(ctx aws.Context, in *iam.PutUserPolicyInput, _ ...request.Option) (*iam.PutUserPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.putPolicy(in.PolicyName, in.PolicyDocument); err != nil {
+		return nil, err
+	}
+
+	return &iam.PutUserPolicyOutput{}, nil
+}
+
+// GetUserPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) GetUserPolicy(in *iam.GetUserPolicyInput) (*iam.GetUserPolicyOutput, error) {
+	return f.GetUserPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// GetUserPolicyWithContext implements the iamiface.IAMAPI interface. The
+// document is URL-encoded, as returned by the IAM API.
+func (f *IAM) GetUserPolicyWithContext(ctx aws.Context, in *iam.GetUserPolicyInput, _ ...request.Option) (*iam.GetUserPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := user.getPolicy("user", in.PolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.GetUserPolicyOutput{UserName: in.UserName, PolicyName: in.PolicyName, PolicyDocument: document}, nil
+}
+
+// DeleteUserPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteUserPolicy(in *iam.DeleteUserPolicyInput) (*iam.DeleteUserPolicyOutput, error) {
+	return f.DeleteUserPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteUserPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteUserPolicyWithContext(ctx aws.Context, in *iam.DeleteUserPolicyInput,
+	_ ...request.Option,
+) (*iam.DeleteUserPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.deletePolicy("user", in.PolicyName); err != nil {
+		return nil, err
+	}
+
+	return &iam.DeleteUserPolicyOutput{}, nil
+}
+
+// ListUserPolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUserPolicies(in *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error) {
+	return f.ListUserPoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListUserPoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUserPoliciesWithContext(ctx aws.Context, in *iam.ListUserPoliciesInput,
+	_ ...request.Option,
+) (*iam.ListUserPoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(user.policyNames(), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListUserPoliciesOutput{PolicyNames: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListUserPoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUserPoliciesPages(in *iam.ListUserPoliciesInput, fn func(*iam.ListUserPoliciesOutput, bool) bool) error {
+	return f.ListUserPoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListUserPoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListUserPoliciesPagesWithContext(ctx aws.Context, in *iam.ListUserPoliciesInput,
+	fn func(*iam.ListUserPoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListUserPoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListUserPoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListUserPoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// AttachGroupPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachGroupPolicy(in *iam.AttachGroupPolicyInput) (*iam.AttachGroupPolicyOutput, error) {
+	return f.AttachGroupPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// AttachGroupPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachGroupPolicyWithContext(ctx aws.Context, in *iam.AttachGroupPolicyInput,
+	_ ...request.Option,
+) (*iam.AttachGroupPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.attach(&group.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.AttachGroupPolicyOutput{}, nil
+}
+
+// DetachGroupPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachGroupPolicy(in *iam.DetachGroupPolicyInput) (*iam.DetachGroupPolicyOutput, error) {
+	return f.DetachGroupPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DetachGroupPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachGroupPolicyWithContext(ctx aws.Context, in *iam.DetachGroupPolicyInput,
+	_ ...request.Option,
+) (*iam.DetachGroupPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.detach(&group.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.DetachGroupPolicyOutput{}, nil
+}
+
+// ListAttachedGroupPolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedGroupPolicies(in *iam.ListAttachedGroupPoliciesInput) (*iam.ListAttachedGroupPoliciesOutput, error) {
+	return f.ListAttachedGroupPoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListAttachedGroupPoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedGroupPoliciesWithContext(ctx aws.Context, in *iam.ListAttachedGroupPoliciesInput,
+	_ ...request.Option,
+) (*iam.ListAttachedGroupPoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(a.attachedPolicies(&group.iamPrincipal, in.PathPrefix), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListAttachedGroupPoliciesOutput{AttachedPolicies: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListAttachedGroupPoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedGroupPoliciesPages(in *iam.ListAttachedGroupPoliciesInput,
+	fn func(*iam.ListAttachedGroupPoliciesOutput, bool) bool,
+) error {
+	return f.ListAttachedGroupPoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListAttachedGroupPoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedGroupPoliciesPagesWithContext(ctx aws.Context, in *iam.ListAttachedGroupPoliciesInput,
+	fn func(*iam.ListAttachedGroupPoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListAttachedGroupPoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListAttachedGroupPoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListAttachedGroupPoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// PutGroupPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) PutGroupPolicy(in *iam.PutGroupPolicyInput) (*iam.PutGroupPolicyOutput, error) {
+	return f.PutGroupPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// PutGroupPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) PutGroupPolicyWithContext(ctx aws.Context, in *iam.PutGroupPolicyInput, _ ...request.Option) (*iam.PutGroupPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := group.putPolicy(in.PolicyName, in.PolicyDocument); err != nil {
+		return nil, err
+	}
+
+	return &iam.PutGroupPolicyOutput{}, nil
+}
+
+// GetGroupPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) GetGroupPolicy(in *iam.GetGroupPolicyInput) (*iam.GetGroupPolicyOutput, error) {
+	return f.GetGroupPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// GetGroupPolicyWithContext implements the iamiface.IAMAPI interface. The
+// document is URL-encoded, as returned by the IAM API.
+func (f *IAM) GetGroupPolicyWithContext(ctx aws.Context, in *iam.GetGroupPolicyInput, _ ...request.Option) (*iam.GetGroupPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := group.getPolicy("group", in.PolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.GetGroupPolicyOutput{GroupName: in.GroupName, PolicyName: in.PolicyName, PolicyDocument: document}, nil
+}
+
+// DeleteGroupPolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteGroupPolicy(in *iam.DeleteGroupPolicyInput) (*iam.DeleteGroupPolicyOutput, error) {
+	return f.DeleteGroupPolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteGroupPolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteGroupPolicyWithContext(ctx aws.Context, in *iam.DeleteGroupPolicyInput,
+	_ ...request.Option,
+) (*iam.DeleteGroupPolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := group.deletePolicy("group", in.PolicyName); err != nil {
+		return nil, err
+	}
+
+	return &iam.DeleteGroupPolicyOutput{}, nil
+}
+
+// ListGroupPolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupPolicies(in *iam.ListGroupPoliciesInput) (*iam.ListGroupPoliciesOutput, error) {
+	return f.ListGroupPoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListGroupPoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupPoliciesWithContext(ctx aws.Context, in *iam.ListGroupPoliciesInput,
+	_ ...request.Option,
+) (*iam.ListGroupPoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := a.group(in.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(group.policyNames(), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListGroupPoliciesOutput{PolicyNames: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListGroupPoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupPoliciesPages(in *iam.ListGroupPoliciesInput, fn func(*iam.ListGroupPoliciesOutput, bool) bool) error {
+	return f.ListGroupPoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListGroupPoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListGroupPoliciesPagesWithContext(ctx aws.Context, in *iam.ListGroupPoliciesInput,
+	fn func(*iam.ListGroupPoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListGroupPoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListGroupPoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListGroupPoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// AttachRolePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachRolePolicy(in *iam.AttachRolePolicyInput) (*iam.AttachRolePolicyOutput, error) {
+	return f.AttachRolePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// AttachRolePolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) AttachRolePolicyWithContext(ctx aws.Context, in *iam.AttachRolePolicyInput,
+	_ ...request.Option,
+) (*iam.AttachRolePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.attach(&role.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.AttachRolePolicyOutput{}, nil
+}
+
+// DetachRolePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachRolePolicy(in *iam.DetachRolePolicyInput) (*iam.DetachRolePolicyOutput, error) {
+	return f.DetachRolePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DetachRolePolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DetachRolePolicyWithContext(ctx aws.Context, in *iam.DetachRolePolicyInput,
+	_ ...request.Option,
+) (*iam.DetachRolePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.detach(&role.iamPrincipal, in.PolicyArn); err != nil {
+		return nil, err
+	}
+
+	return &iam.DetachRolePolicyOutput{}, nil
+}
+
+// ListAttachedRolePolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedRolePolicies(in *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	return f.ListAttachedRolePoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListAttachedRolePoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedRolePoliciesWithContext(ctx aws.Context, in *iam.ListAttachedRolePoliciesInput,
+	_ ...request.Option,
+) (*iam.ListAttachedRolePoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(a.attachedPolicies(&role.iamPrincipal, in.PathPrefix), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListAttachedRolePoliciesOutput{AttachedPolicies: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListAttachedRolePoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedRolePoliciesPages(in *iam.ListAttachedRolePoliciesInput,
+	fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool,
+) error {
+	return f.ListAttachedRolePoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListAttachedRolePoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAttachedRolePoliciesPagesWithContext(ctx aws.Context, in *iam.ListAttachedRolePoliciesInput,
+	fn func(*iam.ListAttachedRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListAttachedRolePoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListAttachedRolePoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListAttachedRolePoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// PutRolePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) PutRolePolicy(in *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+	return f.PutRolePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// PutRolePolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) PutRolePolicyWithContext(ctx aws.Context, in *iam.PutRolePolicyInput, _ ...request.Option) (*iam.PutRolePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := role.putPolicy(in.PolicyName, in.PolicyDocument); err != nil {
+		return nil, err
+	}
+
+	return &iam.PutRolePolicyOutput{}, nil
+}
+
+// GetRolePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) GetRolePolicy(in *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	return f.GetRolePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// GetRolePolicyWithContext implements the iamiface.IAMAPI interface. The
+// document is URL-encoded, as returned by the IAM API.
+func (f *IAM) GetRolePolicyWithContext(ctx aws.Context, in *iam.GetRolePolicyInput, _ ...request.Option) (*iam.GetRolePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := role.getPolicy("role", in.PolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.GetRolePolicyOutput{RoleName: in.RoleName, PolicyName: in.PolicyName, PolicyDocument: document}, nil
+}
+
+// DeleteRolePolicy implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteRolePolicy(in *iam.DeleteRolePolicyInput) (*iam.DeleteRolePolicyOutput, error) {
+	return f.DeleteRolePolicyWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteRolePolicyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteRolePolicyWithContext(ctx aws.Context, in *iam.DeleteRolePolicyInput,
+	_ ...request.Option,
+) (*iam.DeleteRolePolicyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := role.deletePolicy("role", in.PolicyName); err != nil {
+		return nil, err
+	}
+
+	return &iam.DeleteRolePolicyOutput{}, nil
+}
+
+// ListRolePolicies implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolePolicies(in *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	return f.ListRolePoliciesWithContext(aws.BackgroundContext(), in)
+}
+
+// ListRolePoliciesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolePoliciesWithContext(ctx aws.Context, in *iam.ListRolePoliciesInput,
+	_ ...request.Option,
+) (*iam.ListRolePoliciesOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := a.role(in.RoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, marker, truncated, err := paginate(role.policyNames(), in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListRolePoliciesOutput{PolicyNames: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListRolePoliciesPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolePoliciesPages(in *iam.ListRolePoliciesInput, fn func(*iam.ListRolePoliciesOutput, bool) bool) error {
+	return f.ListRolePoliciesPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListRolePoliciesPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListRolePoliciesPagesWithContext(ctx aws.Context, in *iam.ListRolePoliciesInput,
+	fn func(*iam.ListRolePoliciesOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListRolePoliciesOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListRolePoliciesWithContext(ctx, &input)
+	}, func(out *iam.ListRolePoliciesOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// CreateAccessKey implements the iamiface.IAMAPI interface.
+func (f *IAM) CreateAccessKey(in *iam.CreateAccessKeyInput) (*iam.CreateAccessKeyOutput, error) {
+	return f.CreateAccessKeyWithContext(aws.BackgroundContext(), in)
+}
+
+// CreateAccessKeyWithContext implements the iamiface.IAMAPI interface. Users
+// may have up to IAMMaxAccessKeys access keys.
+func (f *IAM) CreateAccessKeyWithContext(ctx aws.Context, in *iam.CreateAccessKeyInput,
+	_ ...request.Option,
+) (*iam.CreateAccessKeyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(u.keys) >= IAMMaxAccessKeys {
+		return nil, iamError(iam.ErrCodeLimitExceededException, http.StatusConflict,
+			"Cannot exceed quota for AccessKeysPerUser: %d", IAMMaxAccessKeys)
+	}
+
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	key := &iam.AccessKey{
+		AccessKeyId:     aws.String(f.newID("AKIA")),
+		CreateDate:      aws.Time(time.Now().UTC()),
+		SecretAccessKey: aws.String(hex.EncodeToString(secret)),
+		Status:          aws.String(iam.StatusTypeActive),
+		UserName:        in.UserName,
+	}
+	u.keys = append(u.keys, key)
+
+	created := *key
+
+	return &iam.CreateAccessKeyOutput{AccessKey: &created}, nil
+}
+
+// ListAccessKeys implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAccessKeys(in *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error) {
+	return f.ListAccessKeysWithContext(aws.BackgroundContext(), in)
+}
+
+// ListAccessKeysWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAccessKeysWithContext(ctx aws.Context, in *iam.ListAccessKeysInput,
+	_ ...request.Option,
+) (*iam.ListAccessKeysOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := a.user(in.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*iam.AccessKeyMetadata, 0, len(u.keys))
+	for _, key := range u.keys {
+		keys = append(keys, &iam.AccessKeyMetadata{
+			AccessKeyId: key.AccessKeyId,
+			CreateDate:  key.CreateDate,
+			Status:      key.Status,
+			UserName:    key.UserName,
+		})
+	}
+
+	page, marker, truncated, err := paginate(keys, in.Marker, in.MaxItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iam.ListAccessKeysOutput{AccessKeyMetadata: page, Marker: marker, IsTruncated: aws.Bool(truncated)}, nil
+}
+
+// ListAccessKeysPages implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAccessKeysPages(in *iam.ListAccessKeysInput, fn func(*iam.ListAccessKeysOutput, bool) bool) error {
+	return f.ListAccessKeysPagesWithContext(aws.BackgroundContext(), in, fn)
+}
+
+// ListAccessKeysPagesWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) ListAccessKeysPagesWithContext(ctx aws.Context, in *iam.ListAccessKeysInput,
+	fn func(*iam.ListAccessKeysOutput, bool) bool, _ ...request.Option,
+) error {
+	return eachPage(in.Marker, func(marker *string) (*iam.ListAccessKeysOutput, error) {
+		input := *in
+		input.Marker = marker
+
+		return f.ListAccessKeysWithContext(ctx, &input)
+	}, func(out *iam.ListAccessKeysOutput) (*string, bool) {
+		return out.Marker, aws.BoolValue(out.IsTruncated)
+	}, fn)
+}
+
+// UpdateAccessKey implements the iamiface.IAMAPI interface.
+func (f *IAM) UpdateAccessKey(in *iam.UpdateAccessKeyInput) (*iam.UpdateAccessKeyOutput, error) {
+	return f.UpdateAccessKeyWithContext(aws.BackgroundContext(), in)
+}
+
+// UpdateAccessKeyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) UpdateAccessKeyWithContext(ctx aws.Context, in *iam.UpdateAccessKeyInput,
+	_ ...request.Option,
+) (*iam.UpdateAccessKeyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(iam.StatusType_Values(), aws.StringValue(in.Status)) {
+		return nil, invalidInput("Invalid status %s.", aws.StringValue(in.Status))
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := a.accessKey(in.UserName, in.AccessKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Status = in.Status
+
+	return &iam.UpdateAccessKeyOutput{}, nil
+}
+
+// DeleteAccessKey implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteAccessKey(in *iam.DeleteAccessKeyInput) (*iam.DeleteAccessKeyOutput, error) {
+	return f.DeleteAccessKeyWithContext(aws.BackgroundContext(), in)
+}
+
+// DeleteAccessKeyWithContext implements the iamiface.IAMAPI interface.
+func (f *IAM) DeleteAccessKeyWithContext(ctx aws.Context, in *iam.DeleteAccessKeyInput,
+	_ ...request.Option,
+) (*iam.DeleteAccessKeyOutput, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+
+	a, err := f.lock(ctx)
+	defer f.unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := a.accessKey(in.UserName, in.AccessKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	u := a.users[aws.StringValue(key.UserName)]
+	u.keys = slices.DeleteFunc(u.keys, func(k *iam.AccessKey) bool { return k == key })
+
+	return &iam.DeleteAccessKeyOutput{}, nil
+}
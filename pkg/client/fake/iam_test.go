@@ -0,0 +1,457 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/fake"
+	"github.com/dell/goobjectscale/pkg/client/model"
+	objscaleIAM "github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+const document = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+
+// requireCode checks that err is an IAM API error with the code.
+func requireCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var awsErr awserr.Error
+	require.ErrorAs(t, err, &awsErr)
+	assert.Equal(t, code, awsErr.Code())
+}
+
+func TestIAM(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Users":       testIAMUsers,
+		"Accounts":    testIAMAccounts,
+		"Groups":      testIAMGroups,
+		"Roles":       testIAMRoles,
+		"Policies":    testIAMPolicies,
+		"Inline":      testIAMInlinePolicies,
+		"AccessKeys":  testIAMAccessKeys,
+		"Pagination":  testIAMPagination,
+		"Validation":  testIAMValidation,
+		"Unsupported": testIAMUnsupported,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testIAMUsers(t *testing.T) {
+	ctx := context.TODO()
+	c := &objscaleIAM.Client{IAM: fake.NewIAM("ns1")}
+
+	user, err := c.CreateUser(ctx, "user1", "/apps/")
+	require.NoError(t, err)
+	assert.Equal(t, "urn:ecs:iam::ns1:user/apps/user1", aws.StringValue(user.Arn))
+	assert.NotEmpty(t, aws.StringValue(user.UserId))
+
+	_, err = c.CreateUser(ctx, "user1", "")
+	requireCode(t, err, iam.ErrCodeEntityAlreadyExistsException)
+	assert.True(t, model.IsAlreadyExists(err))
+
+	_, err = c.CreateUser(ctx, "user2", "")
+	require.NoError(t, err)
+
+	got, err := c.GetUser(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	users, err := c.ListUsers(ctx, "/apps/")
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "user1", aws.StringValue(users[0].UserName))
+
+	require.NoError(t, c.DeleteUser(ctx, "user1"))
+
+	_, err = c.GetUser(ctx, "user1")
+	requireCode(t, err, iam.ErrCodeNoSuchEntityException)
+	assert.True(t, model.IsNotFound(err))
+
+	err = c.DeleteUser(ctx, "user1")
+	assert.True(t, model.IsNotFound(err))
+
+	_, err = c.CreateUser(ctx, "user3", "apps")
+	requireCode(t, err, iam.ErrCodeInvalidInputException)
+}
+
+func testIAMAccounts(t *testing.T) {
+	ctx := context.TODO()
+	ns1 := fake.NewIAM("ns1")
+	ns2 := ns1.WithAccount("ns2")
+
+	_, err := ns1.CreateUser(&iam.CreateUserInput{UserName: aws.String("user1")})
+	require.NoError(t, err)
+
+	// Entities are scoped per account.
+	_, err = ns2.GetUser(&iam.GetUserInput{UserName: aws.String("user1")})
+	requireCode(t, err, iam.ErrCodeNoSuchEntityException)
+
+	out, err := ns2.CreateUser(&iam.CreateUserInput{UserName: aws.String("user1")})
+	require.NoError(t, err)
+	assert.Equal(t, "urn:ecs:iam::ns2:user/user1", aws.StringValue(out.User.Arn))
+
+	// The account of the request context takes precedence.
+	list, err := ns1.ListUsersWithContext(objscaleIAM.WithAccountID(ctx, "ns3"), &iam.ListUsersInput{})
+	require.NoError(t, err)
+	assert.Empty(t, list.Users)
+
+	got, err := ns1.GetUserWithContext(objscaleIAM.WithAccountID(ctx, "ns2"), &iam.GetUserInput{UserName: aws.String("user1")})
+	require.NoError(t, err)
+	assert.Equal(t, out.User.UserId, got.User.UserId)
+
+	_, err = fake.NewIAM("").ListUsers(&iam.ListUsersInput{})
+	requireCode(t, err, iam.ErrCodeInvalidInputException)
+}
+
+func testIAMGroups(t *testing.T) {
+	ctx := context.TODO()
+	c := &objscaleIAM.Client{IAM: fake.NewIAM("ns1")}
+
+	_, err := c.CreateGroup(ctx, "group1", "")
+	require.NoError(t, err)
+
+	_, err = c.CreateGroup(ctx, "group1", "")
+	assert.True(t, model.IsAlreadyExists(err))
+
+	_, err = c.CreateUser(ctx, "user1", "")
+	require.NoError(t, err)
+
+	err = c.AddUserToGroup(ctx, "group1", "user2")
+	assert.True(t, model.IsNotFound(err))
+
+	require.NoError(t, c.AddUserToGroup(ctx, "group1", "user1"))
+	require.NoError(t, c.AddUserToGroup(ctx, "group1", "user1"))
+
+	group, users, err := c.GetGroup(ctx, "group1")
+	require.NoError(t, err)
+	assert.Equal(t, "urn:ecs:iam::ns1:group/group1", aws.StringValue(group.Arn))
+	require.Len(t, users, 1)
+	assert.Equal(t, "user1", aws.StringValue(users[0].UserName))
+
+	groups, err := c.ListGroupsForUser(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+
+	// Members and groups with members cannot be deleted.
+	err = c.DeleteUser(ctx, "user1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	err = c.DeleteGroup(ctx, "group1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	require.NoError(t, c.RemoveUserFromGroup(ctx, "group1", "user1"))
+
+	err = c.RemoveUserFromGroup(ctx, "group1", "user1")
+	assert.True(t, model.IsNotFound(err))
+
+	require.NoError(t, c.DeleteGroup(ctx, "group1"))
+	require.NoError(t, c.DeleteUser(ctx, "user1"))
+
+	groups, err = c.ListGroups(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func testIAMRoles(t *testing.T) {
+	ctx := context.TODO()
+	c := &objscaleIAM.Client{IAM: fake.NewIAM("ns1")}
+
+	_, err := c.CreateRole(ctx, "role1", "", "{")
+	requireCode(t, err, iam.ErrCodeMalformedPolicyDocumentException)
+
+	role, err := c.CreateRole(ctx, "role1", "", document)
+	require.NoError(t, err)
+	assert.Equal(t, "urn:ecs:iam::ns1:role/role1", aws.StringValue(role.Arn))
+	assert.Equal(t, int64(3600), aws.Int64Value(role.MaxSessionDuration))
+
+	_, err = c.CreateRole(ctx, "role1", "", document)
+	assert.True(t, model.IsAlreadyExists(err))
+
+	got, err := c.GetRole(ctx, "role1")
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleId, got.RoleId)
+
+	roles, err := c.ListRoles(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, roles, 1)
+
+	require.NoError(t, c.PutRolePolicy(ctx, "role1", "inline", document))
+
+	err = c.DeleteRole(ctx, "role1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	require.NoError(t, c.DeleteRolePolicy(ctx, "role1", "inline"))
+	require.NoError(t, c.DeleteRole(ctx, "role1"))
+
+	_, err = c.GetRole(ctx, "role1")
+	assert.True(t, model.IsNotFound(err))
+}
+
+func testIAMPolicies(t *testing.T) {
+	ctx := context.TODO()
+	f := fake.NewIAM("ns1")
+	c := &objscaleIAM.Client{IAM: f}
+
+	_, err := c.CreatePolicy(ctx, "policy1", "", "not json")
+	requireCode(t, err, iam.ErrCodeMalformedPolicyDocumentException)
+
+	policy, err := c.CreatePolicy(ctx, "policy1", "", document)
+	require.NoError(t, err)
+	assert.Equal(t, "urn:ecs:iam::ns1:policy/policy1", aws.StringValue(policy.Arn))
+
+	_, err = c.CreatePolicy(ctx, "policy1", "", document)
+	assert.True(t, model.IsAlreadyExists(err))
+
+	version, err := f.GetPolicyVersion(&iam.GetPolicyVersionInput{PolicyArn: policy.Arn, VersionId: policy.DefaultVersionId})
+	require.NoError(t, err)
+	assert.NotEqual(t, document, aws.StringValue(version.PolicyVersion.Document), "document must be URL-encoded")
+
+	_, err = f.GetPolicyVersion(&iam.GetPolicyVersionInput{PolicyArn: policy.Arn, VersionId: aws.String("v2")})
+	requireCode(t, err, iam.ErrCodeNoSuchEntityException)
+
+	_, err = c.CreateUser(ctx, "user1", "")
+	require.NoError(t, err)
+	_, err = c.CreateGroup(ctx, "group1", "")
+	require.NoError(t, err)
+	_, err = c.CreateRole(ctx, "role1", "", document)
+	require.NoError(t, err)
+
+	arn := aws.StringValue(policy.Arn)
+
+	require.NoError(t, c.AttachUserPolicy(ctx, "user1", arn))
+	require.NoError(t, c.AttachUserPolicy(ctx, "user1", arn))
+	require.NoError(t, c.AttachGroupPolicy(ctx, "group1", arn))
+	require.NoError(t, c.AttachRolePolicy(ctx, "role1", arn))
+
+	err = c.AttachRolePolicy(ctx, "role1", "urn:ecs:iam::ns1:policy/missing")
+	assert.True(t, model.IsNotFound(err))
+
+	got, err := c.GetPolicy(ctx, arn)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), aws.Int64Value(got.AttachmentCount))
+
+	for _, list := range []func() ([]*iam.AttachedPolicy, error){
+		func() ([]*iam.AttachedPolicy, error) { return c.ListAttachedUserPolicies(ctx, "user1") },
+		func() ([]*iam.AttachedPolicy, error) { return c.ListAttachedGroupPolicies(ctx, "group1") },
+		func() ([]*iam.AttachedPolicy, error) { return c.ListAttachedRolePolicies(ctx, "role1") },
+	} {
+		attached, err := list()
+		require.NoError(t, err)
+		require.Len(t, attached, 1)
+		assert.Equal(t, "policy1", aws.StringValue(attached[0].PolicyName))
+	}
+
+	local, err := c.ListPolicies(ctx, iam.PolicyScopeTypeLocal)
+	require.NoError(t, err)
+	assert.Len(t, local, 1)
+
+	managed, err := c.ListPolicies(ctx, iam.PolicyScopeTypeAws)
+	require.NoError(t, err)
+	assert.Empty(t, managed)
+
+	// Attached policies and entities with attached policies cannot be deleted.
+	err = c.DeletePolicy(ctx, arn)
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	err = c.DeleteUser(ctx, "user1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	require.NoError(t, c.DetachUserPolicy(ctx, "user1", arn))
+	require.NoError(t, c.DetachGroupPolicy(ctx, "group1", arn))
+	require.NoError(t, c.DetachRolePolicy(ctx, "role1", arn))
+
+	err = c.DetachRolePolicy(ctx, "role1", arn)
+	assert.True(t, model.IsNotFound(err))
+
+	require.NoError(t, c.DeletePolicy(ctx, arn))
+
+	_, err = c.GetPolicy(ctx, arn)
+	assert.True(t, model.IsNotFound(err))
+}
+
+func testIAMInlinePolicies(t *testing.T) {
+	ctx := context.TODO()
+	c := &objscaleIAM.Client{IAM: fake.NewIAM("ns1")}
+
+	_, err := c.CreateUser(ctx, "user1", "")
+	require.NoError(t, err)
+	_, err = c.CreateGroup(ctx, "group1", "")
+	require.NoError(t, err)
+
+	err = c.PutUserPolicy(ctx, "user1", "inline", "{")
+	requireCode(t, err, iam.ErrCodeMalformedPolicyDocumentException)
+
+	require.NoError(t, c.PutUserPolicy(ctx, "user1", "b", document))
+	require.NoError(t, c.PutUserPolicy(ctx, "user1", "a", `{}`))
+	require.NoError(t, c.PutUserPolicy(ctx, "user1", "a", document))
+
+	// Documents are URL-encoded by the fake and decoded by the client.
+	got, err := c.GetUserPolicy(ctx, "user1", "a")
+	require.NoError(t, err)
+	assert.JSONEq(t, document, got)
+
+	names, err := c.ListUserPolicies(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	require.NoError(t, c.PutGroupPolicy(ctx, "group1", "inline", document))
+
+	got, err = c.GetGroupPolicy(ctx, "group1", "inline")
+	require.NoError(t, err)
+	assert.JSONEq(t, document, got)
+
+	names, err = c.ListGroupPolicies(ctx, "group1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inline"}, names)
+
+	_, err = c.GetGroupPolicy(ctx, "group1", "missing")
+	assert.True(t, model.IsNotFound(err))
+
+	err = c.DeleteGroup(ctx, "group1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	require.NoError(t, c.DeleteGroupPolicy(ctx, "group1", "inline"))
+
+	err = c.DeleteGroupPolicy(ctx, "group1", "inline")
+	assert.True(t, model.IsNotFound(err))
+
+	_, err = c.GetRolePolicy(ctx, "role1", "inline")
+	assert.True(t, model.IsNotFound(err))
+}
+
+func testIAMAccessKeys(t *testing.T) {
+	ctx := context.TODO()
+	c := &objscaleIAM.Client{IAM: fake.NewIAM("ns1")}
+
+	_, err := c.CreateAccessKey(ctx, "user1")
+	assert.True(t, model.IsNotFound(err))
+
+	_, err = c.CreateUser(ctx, "user1", "")
+	require.NoError(t, err)
+
+	key1, err := c.CreateAccessKey(ctx, "user1")
+	require.NoError(t, err)
+	assert.Len(t, aws.StringValue(key1.SecretAccessKey), 40)
+	assert.Equal(t, objscaleIAM.AccessKeyActive, aws.StringValue(key1.Status))
+
+	key2, err := c.CreateAccessKey(ctx, "user1")
+	require.NoError(t, err)
+	assert.NotEqual(t, key1.AccessKeyId, key2.AccessKeyId)
+
+	_, err = c.CreateAccessKey(ctx, "user1")
+	requireCode(t, err, iam.ErrCodeLimitExceededException)
+
+	require.NoError(t, c.UpdateAccessKey(ctx, "user1", aws.StringValue(key1.AccessKeyId), objscaleIAM.AccessKeyInactive))
+
+	err = c.UpdateAccessKey(ctx, "user1", aws.StringValue(key1.AccessKeyId), "Paused")
+	requireCode(t, err, iam.ErrCodeInvalidInputException)
+
+	keys, err := c.ListAccessKeys(ctx, "user1")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, objscaleIAM.AccessKeyInactive, aws.StringValue(keys[0].Status))
+	assert.Equal(t, objscaleIAM.AccessKeyActive, aws.StringValue(keys[1].Status))
+
+	err = c.DeleteUser(ctx, "user1")
+	requireCode(t, err, iam.ErrCodeDeleteConflictException)
+
+	require.NoError(t, c.DeleteAccessKey(ctx, "user1", aws.StringValue(key1.AccessKeyId)))
+
+	err = c.DeleteAccessKey(ctx, "user1", aws.StringValue(key1.AccessKeyId))
+	assert.True(t, model.IsNotFound(err))
+
+	require.NoError(t, c.DeleteAccessKey(ctx, "user1", aws.StringValue(key2.AccessKeyId)))
+	require.NoError(t, c.DeleteUser(ctx, "user1"))
+}
+
+func testIAMPagination(t *testing.T) {
+	f := fake.NewIAM("ns1")
+
+	for _, name := range []string{"user3", "user1", "user2"} {
+		_, err := f.CreateUser(&iam.CreateUserInput{UserName: aws.String(name)})
+		require.NoError(t, err)
+	}
+
+	page, err := f.ListUsers(&iam.ListUsersInput{MaxItems: aws.Int64(2)})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 2)
+	assert.True(t, aws.BoolValue(page.IsTruncated))
+	assert.Equal(t, "user1", aws.StringValue(page.Users[0].UserName))
+
+	page, err = f.ListUsers(&iam.ListUsersInput{MaxItems: aws.Int64(2), Marker: page.Marker})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	assert.False(t, aws.BoolValue(page.IsTruncated))
+	assert.Equal(t, "user3", aws.StringValue(page.Users[0].UserName))
+
+	var (
+		names []string
+		pages int
+	)
+
+	err = f.ListUsersPages(&iam.ListUsersInput{MaxItems: aws.Int64(1)}, func(out *iam.ListUsersOutput, last bool) bool {
+		pages++
+
+		for _, u := range out.Users {
+			names = append(names, aws.StringValue(u.UserName))
+		}
+
+		return !last
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []string{"user1", "user2", "user3"}, names)
+
+	// Iteration stops when the callback returns false.
+	pages = 0
+	err = f.ListUsersPages(&iam.ListUsersInput{MaxItems: aws.Int64(1)}, func(*iam.ListUsersOutput, bool) bool {
+		pages++
+
+		return false
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, pages)
+
+	_, err = f.ListUsers(&iam.ListUsersInput{Marker: aws.String("next")})
+	requireCode(t, err, iam.ErrCodeInvalidInputException)
+}
+
+func testIAMValidation(t *testing.T) {
+	f := fake.NewIAM("ns1")
+
+	_, err := f.CreateUser(&iam.CreateUserInput{})
+	requireCode(t, err, "InvalidParameter")
+
+	_, err = f.CreateRole(&iam.CreateRoleInput{RoleName: aws.String("role1")})
+	requireCode(t, err, "InvalidParameter")
+
+	_, err = f.AttachUserPolicy(&iam.AttachUserPolicyInput{UserName: aws.String("user1"), PolicyArn: aws.String("short")})
+	requireCode(t, err, "InvalidParameter")
+}
+
+func testIAMUnsupported(t *testing.T) {
+	f := fake.NewIAM("ns1")
+
+	assert.Panics(t, func() {
+		_, _ = f.CreateSAMLProvider(&iam.CreateSAMLProviderInput{})
+	})
+}
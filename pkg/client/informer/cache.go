@@ -0,0 +1,234 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// KeyFunc returns the key uniquely identifying an object in a Cache.
+type KeyFunc[T any] func(obj T) string
+
+// IndexFunc returns the index values of an object.
+type IndexFunc[T any] func(obj T) []string
+
+// Indexers are index functions by index name.
+type Indexers[T any] map[string]IndexFunc[T]
+
+// eventType is the type of a change detected by Cache.replace.
+type eventType int
+
+const (
+	eventAdd eventType = iota
+	eventUpdate
+	eventDelete
+)
+
+// event is a change detected by Cache.replace.
+type event[T any] struct {
+	typ    eventType
+	oldObj T
+	newObj T
+}
+
+// Cache is a thread-safe, indexed store of objects by key.
+type Cache[T any] struct {
+	key      KeyFunc[T]
+	equal    func(a, b T) bool
+	indexers Indexers[T]
+
+	mu    sync.RWMutex
+	items map[string]T
+	// indices are object keys by index value by index name
+	indices map[string]map[string]map[string]struct{}
+}
+
+// NewCache returns an empty cache. If equal is nil, reflect.DeepEqual is used
+// to detect updated objects.
+func NewCache[T any](key KeyFunc[T], equal func(a, b T) bool, indexers Indexers[T]) *Cache[T] {
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+
+	c := &Cache[T]{
+		key:      key,
+		equal:    equal,
+		indexers: indexers,
+		items:    make(map[string]T),
+		indices:  make(map[string]map[string]map[string]struct{}),
+	}
+
+	for name := range indexers {
+		c.indices[name] = make(map[string]map[string]struct{})
+	}
+
+	return c
+}
+
+// Get returns the object with the key.
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	obj, ok := c.items[key]
+
+	return obj, ok
+}
+
+// Keys returns the sorted keys of all the objects.
+func (c *Cache[T]) Keys() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.items))
+	for key := range c.items {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// List returns all the objects, sorted by key.
+func (c *Cache[T]) List() []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return objectsByKey(c.items, c.items)
+}
+
+// ByIndex returns the objects whose index function returned the value, sorted
+// by key.
+func (c *Cache[T]) ByIndex(indexName, value string) ([]T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	index, ok := c.indices[indexName]
+	if !ok {
+		return nil, fmt.Errorf("index %q: %w", indexName, ErrIndexNotFound)
+	}
+
+	return objectsByKey(c.items, index[value]), nil
+}
+
+// IndexValues returns the sorted values of the index.
+func (c *Cache[T]) IndexValues(indexName string) ([]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	index, ok := c.indices[indexName]
+	if !ok {
+		return nil, fmt.Errorf("index %q: %w", indexName, ErrIndexNotFound)
+	}
+
+	values := make([]string, 0, len(index))
+	for value := range index {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	return values, nil
+}
+
+// objectsByKey returns the items with the keys, sorted by key.
+func objectsByKey[T, V any](items map[string]T, keys map[string]V) []T {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	sort.Strings(sorted)
+
+	objs := make([]T, 0, len(sorted))
+	for _, key := range sorted {
+		objs = append(objs, items[key])
+	}
+
+	return objs
+}
+
+// replace replaces the content of the cache with objs, returning the changes.
+func (c *Cache[T]) replace(objs []T) []event[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var events []event[T]
+
+	seen := make(map[string]struct{}, len(objs))
+
+	for _, obj := range objs {
+		key := c.key(obj)
+		seen[key] = struct{}{}
+
+		old, ok := c.items[key]
+
+		switch {
+		case !ok:
+			events = append(events, event[T]{typ: eventAdd, newObj: obj})
+		case !c.equal(old, obj):
+			c.unindex(key, old)
+			events = append(events, event[T]{typ: eventUpdate, oldObj: old, newObj: obj})
+		default:
+			continue
+		}
+
+		c.items[key] = obj
+		c.index(key, obj)
+	}
+
+	for key, old := range c.items {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		c.unindex(key, old)
+		delete(c.items, key)
+
+		events = append(events, event[T]{typ: eventDelete, oldObj: old})
+	}
+
+	return events
+}
+
+// index adds the object to the indices. The lock must be held.
+func (c *Cache[T]) index(key string, obj T) {
+	for name, fn := range c.indexers {
+		for _, value := range fn(obj) {
+			keys, ok := c.indices[name][value]
+			if !ok {
+				keys = make(map[string]struct{})
+				c.indices[name][value] = keys
+			}
+
+			keys[key] = struct{}{}
+		}
+	}
+}
+
+// unindex removes the object from the indices. The lock must be held.
+func (c *Cache[T]) unindex(key string, obj T) {
+	for name, fn := range c.indexers {
+		for _, value := range fn(obj) {
+			delete(c.indices[name][value], key)
+
+			if len(c.indices[name][value]) == 0 {
+				delete(c.indices[name], value)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/informer"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+func TestCache(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Empty":        testCacheEmpty,
+		"UnknownIndex": testCacheUnknownIndex,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testCacheEmpty(t *testing.T) {
+	c := informer.NewCache(informer.BucketKey, nil, informer.Indexers[model.Bucket]{
+		informer.IndexOwner: informer.BucketOwner,
+	})
+
+	_, ok := c.Get("ns1/bucket1")
+	assert.False(t, ok)
+	assert.Empty(t, c.List())
+	assert.Empty(t, c.Keys())
+
+	buckets, err := c.ByIndex(informer.IndexOwner, "user1")
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+
+	values, err := c.IndexValues(informer.IndexOwner)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func testCacheUnknownIndex(t *testing.T) {
+	c := informer.NewCache(informer.TenantKey, nil, nil)
+
+	_, err := c.ByIndex(informer.IndexNamespace, "ns1")
+	require.ErrorIs(t, err, informer.ErrIndexNotFound)
+
+	_, err = c.IndexValues(informer.IndexNamespace)
+	require.ErrorIs(t, err, informer.ErrIndexNotFound)
+}
@@ -0,0 +1,247 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package informer provides polling informers keeping a local, indexed cache
+// of management API resources and notifying handlers about their changes.
+package informer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultInterval is the polling interval used if Informer.Interval is not set.
+const DefaultInterval = 30 * time.Second
+
+var (
+	// ErrIndexNotFound is returned when a Cache is queried by an unknown index.
+	ErrIndexNotFound = errors.New("index not found")
+
+	// ErrAlreadyRunning is returned when an Informer is run more than once.
+	ErrAlreadyRunning = errors.New("informer already running")
+)
+
+// ListFunc lists all the objects watched by an Informer.
+type ListFunc[T any] func(ctx context.Context) ([]T, error)
+
+// EventHandler is notified about changes of the objects watched by an Informer.
+type EventHandler[T any] interface {
+	// OnAdd is called when an object is listed for the first time.
+	OnAdd(obj T)
+
+	// OnUpdate is called when a listed object differs from the cached one,
+	// and for all the cached objects on resync.
+	OnUpdate(oldObj, newObj T)
+
+	// OnDelete is called when a cached object is no longer listed.
+	OnDelete(obj T)
+}
+
+var _ EventHandler[any] = EventHandlerFuncs[any]{} // interface guard
+
+// EventHandlerFuncs is an EventHandler calling the functions that are set.
+type EventHandlerFuncs[T any] struct {
+	AddFunc    func(obj T)
+	UpdateFunc func(oldObj, newObj T)
+	DeleteFunc func(obj T)
+}
+
+// OnAdd calls AddFunc if it is set.
+func (f EventHandlerFuncs[T]) OnAdd(obj T) {
+	if f.AddFunc != nil {
+		f.AddFunc(obj)
+	}
+}
+
+// OnUpdate calls UpdateFunc if it is set.
+func (f EventHandlerFuncs[T]) OnUpdate(oldObj, newObj T) {
+	if f.UpdateFunc != nil {
+		f.UpdateFunc(oldObj, newObj)
+	}
+}
+
+// OnDelete calls DeleteFunc if it is set.
+func (f EventHandlerFuncs[T]) OnDelete(obj T) {
+	if f.DeleteFunc != nil {
+		f.DeleteFunc(obj)
+	}
+}
+
+// Informer periodically lists objects, keeps them in an indexed Cache and
+// notifies the registered handlers about added, updated and deleted objects.
+// Handlers are called sequentially from the goroutine running the informer.
+type Informer[T any] struct {
+	// ListFunc lists all the watched objects
+	ListFunc ListFunc[T]
+
+	// KeyFunc returns the key uniquely identifying an object
+	KeyFunc KeyFunc[T]
+
+	// EqualFunc reports whether two versions of an object are equal. If nil,
+	// reflect.DeepEqual is used.
+	EqualFunc func(a, b T) bool
+
+	// Indexers are the indices maintained by the cache
+	Indexers Indexers[T]
+
+	// Interval is the polling interval; DefaultInterval if zero
+	Interval time.Duration
+
+	// ResyncPeriod, if set, is the period after which OnUpdate is called for
+	// all the cached objects, even if they did not change
+	ResyncPeriod time.Duration
+
+	// ErrorHandler, if set, is called when listing fails. The cache is left
+	// unchanged until the next successful listing.
+	ErrorHandler func(err error)
+
+	once     sync.Once
+	cache    *Cache[T]
+	running  atomic.Bool
+	synced   atomic.Bool
+	syncedCh chan struct{}
+
+	mu       sync.Mutex
+	handlers []EventHandler[T]
+}
+
+// init lazily creates the cache.
+func (inf *Informer[T]) init() {
+	inf.once.Do(func() {
+		inf.cache = NewCache(inf.KeyFunc, inf.EqualFunc, inf.Indexers)
+		inf.syncedCh = make(chan struct{})
+	})
+}
+
+// Cache returns the cache of the informer.
+func (inf *Informer[T]) Cache() *Cache[T] {
+	inf.init()
+
+	return inf.cache
+}
+
+// AddEventHandler registers the handler. OnAdd is called for all the objects
+// already in the cache before the handler receives further notifications.
+func (inf *Informer[T]) AddEventHandler(handler EventHandler[T]) {
+	inf.init()
+
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+
+	for _, obj := range inf.cache.List() {
+		handler.OnAdd(obj)
+	}
+
+	inf.handlers = append(inf.handlers, handler)
+}
+
+// HasSynced reports whether the first listing succeeded.
+func (inf *Informer[T]) HasSynced() bool {
+	return inf.synced.Load()
+}
+
+// WaitForCacheSync waits until the first listing succeeded or ctx is done,
+// and reports whether the cache is synced.
+func (inf *Informer[T]) WaitForCacheSync(ctx context.Context) bool {
+	inf.init()
+
+	select {
+	case <-inf.syncedCh:
+		return true
+	case <-ctx.Done():
+		return inf.HasSynced()
+	}
+}
+
+// Run lists the objects immediately and then every Interval, until ctx is done.
+func (inf *Informer[T]) Run(ctx context.Context) error {
+	inf.init()
+
+	if !inf.running.CompareAndSwap(false, true) {
+		return ErrAlreadyRunning
+	}
+	defer inf.running.Store(false)
+
+	interval := inf.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastResync := time.Now()
+
+	inf.poll(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			inf.poll(ctx)
+
+			if inf.ResyncPeriod > 0 && time.Since(lastResync) >= inf.ResyncPeriod {
+				inf.resync()
+
+				lastResync = time.Now()
+			}
+		}
+	}
+}
+
+// poll lists the objects and notifies the handlers about the changes.
+func (inf *Informer[T]) poll(ctx context.Context) {
+	objs, err := inf.ListFunc(ctx)
+	if err != nil {
+		if inf.ErrorHandler != nil && ctx.Err() == nil {
+			inf.ErrorHandler(err)
+		}
+
+		return
+	}
+
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+
+	for _, e := range inf.cache.replace(objs) {
+		for _, h := range inf.handlers {
+			switch e.typ {
+			case eventAdd:
+				h.OnAdd(e.newObj)
+			case eventUpdate:
+				h.OnUpdate(e.oldObj, e.newObj)
+			case eventDelete:
+				h.OnDelete(e.oldObj)
+			}
+		}
+	}
+
+	if inf.synced.CompareAndSwap(false, true) {
+		close(inf.syncedCh)
+	}
+}
+
+// resync notifies the handlers about all the cached objects.
+func (inf *Informer[T]) resync() {
+	inf.mu.Lock()
+	defer inf.mu.Unlock()
+
+	for _, obj := range inf.cache.List() {
+		for _, h := range inf.handlers {
+			h.OnUpdate(obj, obj)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/fake"
+	"github.com/dell/goobjectscale/pkg/client/informer"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// source is a thread-safe list of buckets served to an informer.
+type source struct {
+	mu      sync.Mutex
+	buckets []model.Bucket
+	err     error
+}
+
+func (s *source) set(buckets ...model.Bucket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets = buckets
+}
+
+func (s *source) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+func (s *source) list(_ context.Context) ([]model.Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]model.Bucket(nil), s.buckets...), s.err
+}
+
+// recorder records the events delivered to a handler.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) handler() informer.EventHandlerFuncs[model.Bucket] {
+	record := func(format string, args ...any) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.events = append(r.events, fmt.Sprintf(format, args...))
+	}
+
+	return informer.EventHandlerFuncs[model.Bucket]{
+		AddFunc:    func(b model.Bucket) { record("add %s", b.Name) },
+		UpdateFunc: func(o, n model.Bucket) { record("update %s %s->%s", n.Name, o.Owner, n.Owner) },
+		DeleteFunc: func(b model.Bucket) { record("delete %s", b.Name) },
+	}
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.events...)
+}
+
+func bucket(name, owner string, tags ...model.Tag) model.Bucket {
+	return model.Bucket{Name: name, Namespace: "ns1", Owner: owner, Tags: model.TagSet{Tags: tags}}
+}
+
+func TestInformer(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Events":         testInformerEvents,
+		"Indexers":       testInformerIndexers,
+		"LateHandler":    testInformerLateHandler,
+		"Resync":         testInformerResync,
+		"ListError":      testInformerListError,
+		"AlreadyRunning": testInformerAlreadyRunning,
+		"Pagination":     testInformerPagination,
+		"TenantPages":    testInformerTenantPages,
+		"Resources":      testInformerResources,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+// run starts the informer and waits for the cache to sync.
+func run[T any](t *testing.T, inf *informer.Informer[T]) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		assert.NoError(t, inf.Run(ctx))
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	waitCtx, cancelWait := context.WithTimeout(context.TODO(), time.Second)
+	defer cancelWait()
+
+	require.True(t, inf.WaitForCacheSync(waitCtx))
+}
+
+func testInformerEvents(t *testing.T) {
+	src := &source{}
+	src.set(bucket("b1", "user1"), bucket("b2", "user1"))
+
+	rec := &recorder{}
+	inf := &informer.Informer[model.Bucket]{
+		ListFunc: src.list,
+		KeyFunc:  informer.BucketKey,
+		Interval: 5 * time.Millisecond,
+	}
+	inf.AddEventHandler(rec.handler())
+
+	run(t, inf)
+	assert.Equal(t, []string{"add b1", "add b2"}, rec.get())
+
+	src.set(bucket("b1", "user2"), bucket("b3", "user1"))
+
+	assert.Eventually(t, func() bool { return len(rec.get()) == 5 }, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, []string{"add b1", "add b2", "update b1 user1->user2", "add b3", "delete b2"}, rec.get())
+
+	b1, ok := inf.Cache().Get("ns1/b1")
+	require.True(t, ok)
+	assert.Equal(t, "user2", b1.Owner)
+	assert.Equal(t, []string{"ns1/b1", "ns1/b3"}, inf.Cache().Keys())
+}
+
+func testInformerIndexers(t *testing.T) {
+	src := &source{}
+	src.set(
+		bucket("b1", "user1", model.Tag{Key: "env", Value: "prod"}),
+		bucket("b2", "user2", model.Tag{Key: "env", Value: "dev"}),
+		bucket("b3", "user1"),
+	)
+
+	inf := informer.NewBucketInformer(nil, nil, 5*time.Millisecond)
+	inf.ListFunc = src.list
+
+	run(t, inf)
+
+	byOwner, err := inf.Cache().ByIndex(informer.IndexOwner, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, []model.Bucket{bucket("b1", "user1", model.Tag{Key: "env", Value: "prod"}), bucket("b3", "user1")}, byOwner)
+
+	byTag, err := inf.Cache().ByIndex(informer.IndexTag, "env")
+	require.NoError(t, err)
+	assert.Len(t, byTag, 2)
+
+	byTag, err = inf.Cache().ByIndex(informer.IndexTag, "env=dev")
+	require.NoError(t, err)
+	require.Len(t, byTag, 1)
+	assert.Equal(t, "b2", byTag[0].Name)
+
+	// Indices follow updates and deletions.
+	src.set(bucket("b1", "user2"))
+
+	assert.Eventually(t, func() bool {
+		values, err := inf.Cache().IndexValues(informer.IndexOwner)
+
+		return err == nil && len(values) == 1 && values[0] == "user2"
+	}, time.Second, time.Millisecond)
+
+	values, err := inf.Cache().IndexValues(informer.IndexTag)
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	byNamespace, err := inf.Cache().ByIndex(informer.IndexNamespace, "ns1")
+	require.NoError(t, err)
+	assert.Len(t, byNamespace, 1)
+}
+
+func testInformerLateHandler(t *testing.T) {
+	src := &source{}
+	src.set(bucket("b1", "user1"))
+
+	inf := &informer.Informer[model.Bucket]{
+		ListFunc: src.list,
+		KeyFunc:  informer.BucketKey,
+		Interval: 5 * time.Millisecond,
+	}
+
+	run(t, inf)
+
+	rec := &recorder{}
+	inf.AddEventHandler(rec.handler())
+	assert.Equal(t, []string{"add b1"}, rec.get())
+}
+
+func testInformerResync(t *testing.T) {
+	src := &source{}
+	src.set(bucket("b1", "user1"))
+
+	rec := &recorder{}
+	inf := &informer.Informer[model.Bucket]{
+		ListFunc:     src.list,
+		KeyFunc:      informer.BucketKey,
+		Interval:     5 * time.Millisecond,
+		ResyncPeriod: 10 * time.Millisecond,
+	}
+	inf.AddEventHandler(rec.handler())
+
+	run(t, inf)
+
+	assert.Eventually(t, func() bool {
+		events := rec.get()
+
+		return len(events) > 1 && events[1] == "update b1 user1->user1"
+	}, time.Second, time.Millisecond)
+}
+
+func testInformerListError(t *testing.T) {
+	src := &source{}
+	src.set(bucket("b1", "user1"))
+
+	errs := make(chan error, 1)
+	rec := &recorder{}
+	inf := &informer.Informer[model.Bucket]{
+		ListFunc: src.list,
+		KeyFunc:  informer.BucketKey,
+		Interval: 5 * time.Millisecond,
+		ErrorHandler: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	}
+	inf.AddEventHandler(rec.handler())
+
+	run(t, inf)
+
+	unavailable := errors.New("unavailable")
+	src.fail(unavailable)
+
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, unavailable)
+	case <-time.After(time.Second):
+		t.Fatal("error handler not called")
+	}
+
+	// The cache is kept when listing fails.
+	assert.Equal(t, []string{"add b1"}, rec.get())
+	assert.Len(t, inf.Cache().List(), 1)
+}
+
+func testInformerAlreadyRunning(t *testing.T) {
+	src := &source{}
+	inf := &informer.Informer[model.Bucket]{
+		ListFunc: src.list,
+		KeyFunc:  informer.BucketKey,
+		Interval: 5 * time.Millisecond,
+	}
+
+	run(t, inf)
+	require.ErrorIs(t, inf.Run(context.TODO()), informer.ErrAlreadyRunning)
+}
+
+// pagedBuckets serves the buckets one per page.
+type pagedBuckets struct {
+	api.BucketsInterface
+
+	items   []model.Bucket
+	markers []string
+}
+
+func (p *pagedBuckets) List(_ context.Context, params map[string]string) (*model.BucketList, error) {
+	p.markers = append(p.markers, params[informer.ParamMarker])
+
+	i := 0
+	if marker, ok := params[informer.ParamMarker]; ok {
+		_, err := fmt.Sscanf(marker, "page-%d", &i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := &model.BucketList{Items: p.items[i : i+1]}
+	if i+1 < len(p.items) {
+		list.NextMarker = fmt.Sprintf("page-%d", i+1)
+	}
+
+	return list, nil
+}
+
+func testInformerPagination(t *testing.T) {
+	buckets := &pagedBuckets{items: []model.Bucket{bucket("b1", "u"), bucket("b2", "u"), bucket("b3", "u")}}
+	params := map[string]string{"namespace": "ns1"}
+
+	all, err := informer.ListAllBuckets(context.TODO(), buckets, params)
+	require.NoError(t, err)
+	assert.Equal(t, buckets.items, all)
+	assert.Equal(t, []string{"", "page-1", "page-2"}, buckets.markers)
+
+	// The params of the caller are not modified.
+	assert.Equal(t, map[string]string{"namespace": "ns1"}, params)
+}
+
+// pagedTenants serves the tenants one per page.
+type pagedTenants struct {
+	api.TenantsInterface
+
+	items   []model.Tenant
+	markers []string
+}
+
+func (p *pagedTenants) List(_ context.Context, params map[string]string) (*model.TenantList, error) {
+	p.markers = append(p.markers, params[informer.ParamMarker])
+
+	i := 0
+	if marker, ok := params[informer.ParamMarker]; ok {
+		_, err := fmt.Sscanf(marker, "page-%d", &i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := &model.TenantList{Items: p.items[i : i+1]}
+	if i+1 < len(p.items) {
+		list.NextMarker = fmt.Sprintf("page-%d", i+1)
+	}
+
+	return list, nil
+}
+
+func testInformerTenantPages(t *testing.T) {
+	tenants := &pagedTenants{items: []model.Tenant{{ID: "t1"}, {ID: "t2"}}}
+
+	all, err := informer.ListAllTenants(context.TODO(), tenants, nil)
+	require.NoError(t, err)
+	assert.Equal(t, tenants.items, all)
+	assert.Equal(t, []string{"", "page-1"}, tenants.markers)
+}
+
+func testInformerResources(t *testing.T) {
+	clientset := fake.NewClientSet(
+		&model.Bucket{Name: "b1", Namespace: "ns1"},
+		&model.BlobUser{UserID: "user1", Namespace: "ns1"},
+		&model.Tenant{ID: "tenant1"},
+	)
+
+	buckets := informer.NewBucketInformer(clientset.Buckets(), nil, time.Minute)
+	users := informer.NewObjectUserInformer(clientset.ObjectUser(), nil, time.Minute)
+	tenants := informer.NewTenantInformer(clientset.Tenants(), nil, time.Minute)
+
+	run(t, buckets)
+	run(t, users)
+	run(t, tenants)
+
+	_, ok := buckets.Cache().Get("ns1/b1")
+	assert.True(t, ok)
+
+	byNamespace, err := users.Cache().ByIndex(informer.IndexNamespace, "ns1")
+	require.NoError(t, err)
+	assert.Len(t, byNamespace, 1)
+
+	_, ok = tenants.Cache().Get("tenant1")
+	assert.True(t, ok)
+}
@@ -0,0 +1,197 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/dell/goobjectscale/pkg/client/api"
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// ParamMarker is the listing parameter carrying the marker of the next page.
+const ParamMarker = "marker"
+
+// Index names.
+const (
+	// IndexNamespace indexes objects by namespace.
+	IndexNamespace = "namespace"
+
+	// IndexOwner indexes buckets by owner.
+	IndexOwner = "owner"
+
+	// IndexTag indexes buckets by tag, both by "key" and by "key=value".
+	IndexTag = "tag"
+)
+
+// listPages calls list with the marker of the next page until the returned
+// marker is empty or repeated.
+func listPages[T any](ctx context.Context, params map[string]string,
+	list func(ctx context.Context, params map[string]string) ([]T, string, error),
+) ([]T, error) {
+	var (
+		all    []T
+		marker string
+	)
+
+	params = maps.Clone(params)
+	if params == nil {
+		params = make(map[string]string)
+	}
+
+	for {
+		items, next, err := list(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if next == "" || next == marker {
+			return all, nil
+		}
+
+		marker = next
+		params[ParamMarker] = marker
+	}
+}
+
+// ListAllBuckets lists the buckets, following all the pages of the listing.
+func ListAllBuckets(ctx context.Context, buckets api.BucketsInterface, params map[string]string) ([]model.Bucket, error) {
+	return listPages(ctx, params, func(ctx context.Context, params map[string]string) ([]model.Bucket, string, error) {
+		list, err := buckets.List(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return list.Items, list.NextMarker, nil
+	})
+}
+
+// ListAllObjectUsers lists the object users, following all the pages of the listing.
+func ListAllObjectUsers(ctx context.Context, users api.ObjectUserInterface, params map[string]string) ([]model.BlobUser, error) {
+	return listPages(ctx, params, func(ctx context.Context, params map[string]string) ([]model.BlobUser, string, error) {
+		list, err := users.List(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return list.BlobUser, list.NextMarker, nil
+	})
+}
+
+// ListAllTenants lists the tenants, following all the pages of the listing.
+// The recorded tenant listings carry no marker, but they have the layout of
+// the paged bucket listings, so a marker is followed if one is returned.
+func ListAllTenants(ctx context.Context, tenants api.TenantsInterface, params map[string]string) ([]model.Tenant, error) {
+	return listPages(ctx, params, func(ctx context.Context, params map[string]string) ([]model.Tenant, string, error) {
+		list, err := tenants.List(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return list.Items, list.NextMarker, nil
+	})
+}
+
+// BucketKey returns the key of a bucket: "namespace/name", or the name if the
+// namespace is not set.
+func BucketKey(bucket model.Bucket) string {
+	if bucket.Namespace == "" {
+		return bucket.Name
+	}
+
+	return bucket.Namespace + "/" + bucket.Name
+}
+
+// BucketNamespace is an IndexFunc returning the namespace of a bucket.
+func BucketNamespace(bucket model.Bucket) []string {
+	return []string{bucket.Namespace}
+}
+
+// BucketOwner is an IndexFunc returning the owner of a bucket.
+func BucketOwner(bucket model.Bucket) []string {
+	return []string{bucket.Owner}
+}
+
+// BucketTags is an IndexFunc returning the tags of a bucket, both as "key"
+// and as "key=value".
+func BucketTags(bucket model.Bucket) []string {
+	values := make([]string, 0, 2*len(bucket.Tags.Tags)) //nolint:gomnd
+
+	for _, tag := range bucket.Tags.Tags {
+		values = append(values, tag.Key, tag.Key+"="+tag.Value)
+	}
+
+	return values
+}
+
+// NewBucketInformer returns an informer of the buckets listed with the params,
+// indexed by namespace, owner and tag.
+func NewBucketInformer(buckets api.BucketsInterface, params map[string]string, interval time.Duration) *Informer[model.Bucket] {
+	return &Informer[model.Bucket]{
+		ListFunc: func(ctx context.Context) ([]model.Bucket, error) {
+			return ListAllBuckets(ctx, buckets, params)
+		},
+		KeyFunc: BucketKey,
+		Indexers: Indexers[model.Bucket]{
+			IndexNamespace: BucketNamespace,
+			IndexOwner:     BucketOwner,
+			IndexTag:       BucketTags,
+		},
+		Interval: interval,
+	}
+}
+
+// TenantKey returns the key of a tenant, its ID.
+func TenantKey(tenant model.Tenant) string {
+	return tenant.ID
+}
+
+// NewTenantInformer returns an informer of the tenants listed with the params.
+func NewTenantInformer(tenants api.TenantsInterface, params map[string]string, interval time.Duration) *Informer[model.Tenant] {
+	return &Informer[model.Tenant]{
+		ListFunc: func(ctx context.Context) ([]model.Tenant, error) {
+			return ListAllTenants(ctx, tenants, params)
+		},
+		KeyFunc:  TenantKey,
+		Interval: interval,
+	}
+}
+
+// ObjectUserKey returns the key of an object user, its user ID.
+func ObjectUserKey(user model.BlobUser) string {
+	return user.UserID
+}
+
+// ObjectUserNamespace is an IndexFunc returning the namespace of an object user.
+func ObjectUserNamespace(user model.BlobUser) []string {
+	return []string{user.Namespace}
+}
+
+// NewObjectUserInformer returns an informer of the object users listed with
+// the params, indexed by namespace.
+func NewObjectUserInformer(users api.ObjectUserInterface, params map[string]string, interval time.Duration) *Informer[model.BlobUser] {
+	return &Informer[model.BlobUser]{
+		ListFunc: func(ctx context.Context) ([]model.BlobUser, error) {
+			return ListAllObjectUsers(ctx, users, params)
+		},
+		KeyFunc: ObjectUserKey,
+		Indexers: Indexers[model.BlobUser]{
+			IndexNamespace: ObjectUserNamespace,
+		},
+		Interval: interval,
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidACL is returned when a bucket ACL does not pass validation.
+var ErrInvalidACL = errors.New("invalid bucket ACL")
+
+// ACLPermission is a permission granted by a bucket ACL entry.
+type ACLPermission string
+
+// Bucket ACL permissions.
+const (
+	PermissionRead        ACLPermission = "read"
+	PermissionWrite       ACLPermission = "write"
+	PermissionExecute     ACLPermission = "execute"
+	PermissionFullControl ACLPermission = "full_control"
+	PermissionReadACL     ACLPermission = "read_acl"
+	PermissionWriteACL    ACLPermission = "write_acl"
+)
+
+// ACLPermissions are the permissions supported in bucket ACL entries.
+var ACLPermissions = []ACLPermission{
+	PermissionRead, PermissionWrite, PermissionExecute, PermissionFullControl, PermissionReadACL, PermissionWriteACL,
+}
+
+// BucketACL is the access control list of a bucket.
+type BucketACL struct {
+	// XMLName is the name of the xml tag used XML marshalling
+	XMLName xml.Name `json:"-" xml:"bucket_acl"`
+
+	// Bucket is the name of the bucket
+	Bucket string `json:"bucket" xml:"bucket"`
+
+	// Namespace is the namespace of the bucket
+	Namespace string `json:"namespace" xml:"namespace"`
+
+	// ACL is the access control list of the bucket
+	ACL ACL `json:"acl" xml:"acl"`
+}
+
+// ACL holds the owner of a bucket and the permissions granted on it.
+type ACL struct {
+	// Owner is the object user owning the bucket
+	Owner string `json:"owner,omitempty" xml:"owner,omitempty"`
+
+	// UserACL are the permissions granted to object users
+	UserACL []UserACL `json:"user_acl,omitempty" xml:"user_acl,omitempty"`
+
+	// GroupACL are the permissions granted to predefined groups
+	GroupACL []GroupACL `json:"group_acl,omitempty" xml:"group_acl,omitempty"`
+
+	// CustomGroupACL are the permissions granted to custom groups
+	CustomGroupACL []CustomGroupACL `json:"customgroup_acl,omitempty" xml:"customgroup_acl,omitempty"`
+}
+
+// UserACL are the permissions granted to an object user.
+type UserACL struct {
+	// User is the name of the object user
+	User string `json:"user" xml:"user"`
+
+	// Permission are the permissions granted to the user
+	Permission []ACLPermission `json:"permission" xml:"permission"`
+}
+
+// GroupACL are the permissions granted to a predefined group, such as public
+// or all_users.
+type GroupACL struct {
+	// Group is the name of the group
+	Group string `json:"group" xml:"group"`
+
+	// Permission are the permissions granted to the group
+	Permission []ACLPermission `json:"permission" xml:"permission"`
+}
+
+// CustomGroupACL are the permissions granted to a custom group.
+type CustomGroupACL struct {
+	// CustomGroup is the name of the custom group
+	CustomGroup string `json:"customgroup" xml:"customgroup"`
+
+	// Permission are the permissions granted to the custom group
+	Permission []ACLPermission `json:"permission" xml:"permission"`
+}
+
+// Validate checks that the ACL names its bucket and that every entry has a
+// grantee and known permissions. All problems found are reported, and the
+// returned error matches ErrInvalidACL.
+func (a BucketACL) Validate() error {
+	var errs []error
+
+	if a.Bucket == "" {
+		errs = append(errs, errors.New("bucket is required"))
+	}
+
+	for i, entry := range a.ACL.UserACL {
+		errs = append(errs, validateACLEntry(fmt.Sprintf("user_acl[%d]", i), entry.User, entry.Permission)...)
+	}
+
+	for i, entry := range a.ACL.GroupACL {
+		errs = append(errs, validateACLEntry(fmt.Sprintf("group_acl[%d]", i), entry.Group, entry.Permission)...)
+	}
+
+	for i, entry := range a.ACL.CustomGroupACL {
+		errs = append(errs, validateACLEntry(fmt.Sprintf("customgroup_acl[%d]", i), entry.CustomGroup, entry.Permission)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidACL, errors.Join(errs...))
+}
+
+// validateACLEntry returns the problems found in an ACL entry.
+func validateACLEntry(field, grantee string, permissions []ACLPermission) []error {
+	var errs []error
+
+	if grantee == "" {
+		errs = append(errs, fmt.Errorf("%s: grantee is required", field))
+	}
+
+	if len(permissions) == 0 {
+		errs = append(errs, fmt.Errorf("%s: at least one permission is required", field))
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(ACLPermissions, permission) {
+			errs = append(errs, fmt.Errorf("%s: unknown permission %q", field, permission))
+		}
+	}
+
+	return errs
+}
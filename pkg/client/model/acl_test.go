@@ -0,0 +1,81 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketACL(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Validate": testBucketACLValidate,
+		"XML":      testBucketACLXML,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testBucketACLValidate(t *testing.T) {
+	read := []model.ACLPermission{model.PermissionRead}
+
+	for name, tc := range map[string]struct {
+		acl   model.BucketACL
+		valid bool
+	}{
+		"owner only": {acl: model.BucketACL{Bucket: "b", ACL: model.ACL{Owner: "o"}}, valid: true},
+		"all entries": {acl: model.BucketACL{Bucket: "b", ACL: model.ACL{
+			UserACL:        []model.UserACL{{User: "u", Permission: model.ACLPermissions}},
+			GroupACL:       []model.GroupACL{{Group: "public", Permission: read}},
+			CustomGroupACL: []model.CustomGroupACL{{CustomGroup: "c", Permission: read}},
+		}}, valid: true},
+		"no bucket":          {acl: model.BucketACL{}},
+		"no user":            {acl: model.BucketACL{Bucket: "b", ACL: model.ACL{UserACL: []model.UserACL{{Permission: read}}}}},
+		"no permission":      {acl: model.BucketACL{Bucket: "b", ACL: model.ACL{GroupACL: []model.GroupACL{{Group: "public"}}}}},
+		"unknown permission": {acl: model.BucketACL{Bucket: "b", ACL: model.ACL{CustomGroupACL: []model.CustomGroupACL{{CustomGroup: "c", Permission: []model.ACLPermission{"list"}}}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.acl.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrInvalidACL)
+			}
+		})
+	}
+}
+
+func testBucketACLXML(t *testing.T) {
+	acl := model.BucketACL{
+		Bucket:    "b",
+		Namespace: "ns",
+		ACL: model.ACL{
+			Owner:   "o",
+			UserACL: []model.UserACL{{User: "u", Permission: []model.ACLPermission{model.PermissionRead, model.PermissionWriteACL}}},
+		},
+	}
+
+	out, err := xml.Marshal(acl)
+	require.NoError(t, err)
+	assert.Equal(t, `<bucket_acl><bucket>b</bucket><namespace>ns</namespace><acl><owner>o</owner>`+
+		`<user_acl><user>u</user><permission>read</permission><permission>write_acl</permission></user_acl>`+
+		`</acl></bucket_acl>`, string(out))
+
+	decoded := model.BucketACL{}
+	require.NoError(t, xml.Unmarshal(out, &decoded))
+	assert.Equal(t, acl.ACL, decoded.ACL)
+}
@@ -0,0 +1,275 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrImmutableField is returned when the desired specification of a resource
+// differs from the live resource in fields that cannot be updated.
+var ErrImmutableField = fmt.Errorf("immutable field: %w", ErrConflict)
+
+// ApplyResult is the outcome of applying a desired resource specification.
+type ApplyResult string
+
+// Apply results.
+const (
+	// ApplyCreated means the resource was missing and has been created.
+	ApplyCreated ApplyResult = "created"
+
+	// ApplyUpdated means the resource existed and its differing mutable
+	// fields have been updated.
+	ApplyUpdated ApplyResult = "updated"
+
+	// ApplyUnchanged means the resource existed and matched the specification.
+	ApplyUnchanged ApplyResult = "unchanged"
+)
+
+// QuotaUpdate returns the quota update bringing the live bucket to the quota
+// of the desired bucket, and whether any update is needed. Zero quota fields
+// of the desired bucket are not compared.
+func (b Bucket) QuotaUpdate(live Bucket) (BucketQuotaUpdate, bool) {
+	quota := BucketQuota{
+		BucketName:            live.Name,
+		Namespace:             live.Namespace,
+		BlockSize:             live.BlockSize,
+		BlockSizeCount:        live.BlockSizeCount,
+		NotificationSize:      live.NotificationSize,
+		NotificationSizeCount: live.NotificationSizeCount,
+	}
+
+	changed := false
+
+	for _, field := range []struct{ desired, live int64 }{
+		{b.BlockSize, live.BlockSize},
+		{b.BlockSizeCount, live.BlockSizeCount},
+		{b.NotificationSize, live.NotificationSize},
+		{b.NotificationSizeCount, live.NotificationSizeCount},
+	} {
+		if field.desired != 0 && field.desired != field.live {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return BucketQuotaUpdate{}, false
+	}
+
+	if b.BlockSize != 0 {
+		quota.BlockSize = b.BlockSize
+	}
+
+	if b.BlockSizeCount != 0 {
+		quota.BlockSizeCount = b.BlockSizeCount
+	}
+
+	if b.NotificationSize != 0 {
+		quota.NotificationSize = b.NotificationSize
+	}
+
+	if b.NotificationSizeCount != 0 {
+		quota.NotificationSizeCount = b.NotificationSizeCount
+	}
+
+	return BucketQuotaUpdate{BucketQuota: quota}, true
+}
+
+// ImmutableChanges returns the names of the fields of the desired bucket that
+// differ from the live bucket but cannot be updated once the bucket exists.
+// Zero fields of the desired bucket are not compared, so boolean options can
+// only be detected as changed when they are requested.
+func (b Bucket) ImmutableChanges(live Bucket) []string {
+	var fields []string
+
+	for _, field := range []struct {
+		name    string
+		changed bool
+	}{
+		{"api_type", b.APIType != "" && b.APIType != live.APIType},
+		{"audit_delete_expiration", b.AuditDeleteExpiration != 0 && b.AuditDeleteExpiration != live.AuditDeleteExpiration},
+		{"is_encryption_enabled", b.EncryptionEnabled && !live.EncryptionEnabled},
+		{"softquota", b.SoftQuota != "" && b.SoftQuota != live.SoftQuota},
+		{"fs_access_enabled", b.FSEnabled && !live.FSEnabled},
+		{"locked", b.Locked && !live.Locked},
+		{"vpool", b.ReplicationGroup != "" && b.ReplicationGroup != live.ReplicationGroup},
+		{"owner", b.Owner != "" && b.Owner != live.Owner},
+		{"is_stale_allowed", b.StaleAllowed && !live.StaleAllowed},
+		{"is_tso_read_only", b.TSOReadOnly && !live.TSOReadOnly},
+		{"TagSet", len(b.Tags.Tags) != 0 && !slices.Equal(b.Tags.Tags, live.Tags.Tags)},
+		{"search_metadata", b.SearchMetadata.Enabled && !b.SearchMetadata.matches(live.SearchMetadata)},
+		{"storagePolicy", b.StoragePolicy != "" && b.StoragePolicy != live.StoragePolicy},
+	} {
+		if field.changed {
+			fields = append(fields, field.name)
+		}
+	}
+
+	return fields
+}
+
+// CheckImmutable returns ErrImmutableField listing the fields of the desired
+// bucket that differ from the live bucket but cannot be updated.
+func (b Bucket) CheckImmutable(live Bucket) error {
+	fields := b.ImmutableChanges(live)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("bucket %s: %w: %s", live.Name, ErrImmutableField, strings.Join(fields, ", "))
+}
+
+// matches reports whether the live search metadata is enabled with the keys
+// of the desired one.
+func (m SearchMetadata) matches(live SearchMetadata) bool {
+	return live.Enabled &&
+		(m.MaxKeys == 0 || m.MaxKeys == live.MaxKeys) &&
+		slices.Equal(m.Metadata, live.Metadata)
+}
+
+// RetentionUpdate returns the retention bringing the live bucket to the
+// default retention and the min/max governor of the desired bucket, and
+// whether any update is needed. A zero default retention or governor of the
+// desired bucket is not compared.
+func (b Bucket) RetentionUpdate(live Bucket) (BucketRetention, bool) {
+	update := BucketRetention{
+		DefaultRetention: live.DefaultRetention,
+		Governor:         live.MinMaxGovenor,
+	}
+
+	if b.DefaultRetention != 0 {
+		update.DefaultRetention = b.DefaultRetention
+	}
+
+	if limits(b.MinMaxGovenor) != (MinMaxGovenor{}) {
+		update.Governor.EnforceRetention = b.EnforceRetention
+		update.Governor.MinimumFixedRetention = b.MinimumFixedRetention
+		update.Governor.MinimumVariableRetention = b.MinimumVariableRetention
+		update.Governor.MaximumFixedRetention = b.MaximumFixedRetention
+		update.Governor.MaximumVariableRetention = b.MaximumVariableRetention
+	}
+
+	changed := !update.Equal(BucketRetention{DefaultRetention: live.DefaultRetention, Governor: live.MinMaxGovenor})
+
+	return update, changed
+}
+
+// DefaultGroupUpdate returns the default group and permissions of the desired
+// bucket, and whether they differ from the live bucket. The permissions are
+// only compared if the desired bucket has a default group.
+func (b Bucket) DefaultGroupUpdate(live Bucket) (string, DefaultGroupPermissions, bool) {
+	if b.DefaultGroup == "" {
+		return "", DefaultGroupPermissions{}, false
+	}
+
+	permissions := b.DefaultGroupPermissions()
+	changed := b.DefaultGroup != live.DefaultGroup || permissions != live.DefaultGroupPermissions()
+
+	return b.DefaultGroup, permissions, changed
+}
+
+// Update returns the update bringing the live tenant to the desired tenant,
+// and whether any update is needed. Only the alias and the default bucket
+// block size can be updated; zero fields of the desired tenant are not
+// compared.
+func (t TenantCreate) Update(live Tenant) (TenantUpdate, bool) {
+	update := TenantUpdate{
+		BucketBlockSize: live.BucketBlockSize,
+		Alias:           live.Alias,
+	}
+
+	if t.BucketBlockSize != 0 {
+		update.BucketBlockSize = t.BucketBlockSize
+	}
+
+	if t.Alias != "" {
+		update.Alias = t.Alias
+	}
+
+	changed := update.BucketBlockSize != live.BucketBlockSize || update.Alias != live.Alias
+
+	return update, changed
+}
+
+// ImmutableChanges returns the names of the fields of the desired tenant that
+// differ from the live tenant but cannot be updated once the tenant exists.
+// Like for buckets, boolean options are only detected as changed when they are
+// requested.
+func (t TenantCreate) ImmutableChanges(live Tenant) []string {
+	var fields []string
+
+	for _, field := range []struct {
+		name    string
+		changed bool
+	}{
+		{"is_encryption_enabled", t.EncryptionEnabled && !live.EncryptionEnabled},
+		{"is_compliance_enabled", t.ComplianceEnabled && !live.ComplianceEnabled},
+	} {
+		if field.changed {
+			fields = append(fields, field.name)
+		}
+	}
+
+	return fields
+}
+
+// CheckImmutable returns ErrImmutableField listing the fields of the desired
+// tenant that differ from the live tenant but cannot be updated.
+func (t TenantCreate) CheckImmutable(live Tenant) error {
+	fields := t.ImmutableChanges(live)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("tenant %s: %w: %s", live.ID, ErrImmutableField, strings.Join(fields, ", "))
+}
+
+// Merge returns the live alert policy with the non-zero fields of the desired
+// policy applied, and whether it differs from the live policy. The name and
+// the creator of the policy are not updated.
+func (p AlertPolicy) Merge(live AlertPolicy) (AlertPolicy, bool) {
+	merged := live
+
+	mergeString(&merged.MetricType, p.MetricType)
+	mergeString(&merged.MetricName, p.MetricName)
+	mergeString(&merged.IsEnabled, p.IsEnabled)
+	mergeString(&merged.IsPerInstanceMetric, p.IsPerInstanceMetric)
+	mergeString(&merged.PeriodUnits, p.PeriodUnits)
+	mergeString(&merged.Statistic, p.Statistic)
+	mergeString(&merged.Operator, p.Operator)
+	mergeString(&merged.Condition.ThresholdUnits, p.Condition.ThresholdUnits)
+	mergeString(&merged.Condition.ThresholdValue, p.Condition.ThresholdValue)
+	mergeString(&merged.Condition.SeverityType, p.Condition.SeverityType)
+
+	if p.Period != 0 {
+		merged.Period = p.Period
+	}
+
+	if p.DatapointsToConsider != 0 {
+		merged.DatapointsToConsider = p.DatapointsToConsider
+	}
+
+	if p.DatapointsToAlert != 0 {
+		merged.DatapointsToAlert = p.DatapointsToAlert
+	}
+
+	return merged, merged != live
+}
+
+func mergeString(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
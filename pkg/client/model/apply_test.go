@@ -0,0 +1,151 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"BucketQuota":        testApplyBucketQuota,
+		"BucketImmutable":    testApplyBucketImmutable,
+		"BucketRetention":    testApplyBucketRetention,
+		"BucketDefaultGroup": testApplyBucketDefaultGroup,
+		"Tenant":             testApplyTenant,
+		"TenantImmutable":    testApplyTenantImmutable,
+		"AlertPolicy":        testApplyAlertPolicy,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testApplyBucketQuota(t *testing.T) {
+	live := model.Bucket{Name: "bucket1", Namespace: "ns1", BlockSize: 10, NotificationSize: 5, ReplicationGroup: "rg1"}
+
+	_, changed := model.Bucket{Name: "bucket1", ReplicationGroup: "rg2"}.QuotaUpdate(live)
+	assert.False(t, changed)
+
+	_, changed = model.Bucket{BlockSize: 10}.QuotaUpdate(live)
+	assert.False(t, changed)
+
+	update, changed := model.Bucket{NotificationSize: 8}.QuotaUpdate(live)
+	assert.True(t, changed)
+	assert.Equal(t, "bucket1", update.BucketName)
+	assert.Equal(t, "ns1", update.Namespace)
+	assert.Equal(t, int64(10), update.BlockSize)
+	assert.Equal(t, int64(8), update.NotificationSize)
+}
+
+func testApplyBucketImmutable(t *testing.T) {
+	live := model.Bucket{Name: "bucket1", APIType: "S3", ReplicationGroup: "rg1", FSEnabled: true}
+	live.SearchMetadata = model.SearchMetadata{Enabled: true, MaxKeys: 5, Metadata: []model.Metadata{model.UserMetadata("key1", model.DatatypeString)}}
+
+	assert.Empty(t, model.Bucket{Name: "bucket1", ReplicationGroup: "rg1", BlockSize: 20}.ImmutableChanges(live))
+	assert.Empty(t, model.Bucket{SearchMetadata: live.SearchMetadata}.ImmutableChanges(live))
+	assert.NoError(t, model.Bucket{FSEnabled: true}.CheckImmutable(live))
+
+	desired := model.Bucket{APIType: "S3", ReplicationGroup: "rg2", EncryptionEnabled: true, Owner: "user1"}
+	desired.SearchMetadata = model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{model.UserMetadata("key2", model.DatatypeString)}}
+	assert.Equal(t, []string{"is_encryption_enabled", "vpool", "owner", "search_metadata"}, desired.ImmutableChanges(live))
+
+	err := desired.CheckImmutable(live)
+	assert.ErrorIs(t, err, model.ErrImmutableField)
+	assert.True(t, model.IsConflict(err))
+}
+
+func testApplyBucketRetention(t *testing.T) {
+	live := model.Bucket{Name: "bucket1", DefaultRetention: 10}
+	live.MinMaxGovenor = model.MinMaxGovenor{MinimumFixedRetention: 5, Link: model.Link{HREF: "/governor"}}
+
+	_, changed := model.Bucket{BlockSize: 10}.RetentionUpdate(live)
+	assert.False(t, changed)
+
+	_, changed = model.Bucket{DefaultRetention: 10, MinMaxGovenor: model.MinMaxGovenor{MinimumFixedRetention: 5}}.RetentionUpdate(live)
+	assert.False(t, changed)
+
+	update, changed := model.Bucket{DefaultRetention: 20}.RetentionUpdate(live)
+	assert.True(t, changed)
+	assert.Equal(t, int64(20), update.DefaultRetention)
+	assert.Equal(t, int64(5), update.Governor.MinimumFixedRetention)
+
+	update, changed = model.Bucket{MinMaxGovenor: model.MinMaxGovenor{EnforceRetention: true, MaximumFixedRetention: 30}}.RetentionUpdate(live)
+	assert.True(t, changed)
+	assert.Equal(t, int64(10), update.DefaultRetention)
+	assert.True(t, update.Governor.EnforceRetention)
+	assert.Equal(t, int64(0), update.Governor.MinimumFixedRetention)
+	assert.Equal(t, int64(30), update.Governor.MaximumFixedRetention)
+}
+
+func testApplyBucketDefaultGroup(t *testing.T) {
+	live := model.Bucket{Name: "bucket1", DefaultGroup: "group1", DefaultGroupFileReadPermission: true}
+
+	_, _, changed := model.Bucket{}.DefaultGroupUpdate(live)
+	assert.False(t, changed)
+
+	_, _, changed = model.Bucket{DefaultGroup: "group1", DefaultGroupFileReadPermission: true}.DefaultGroupUpdate(live)
+	assert.False(t, changed)
+
+	group, permissions, changed := model.Bucket{DefaultGroup: "group1", DefaultGroupDirReadPermission: true}.DefaultGroupUpdate(live)
+	assert.True(t, changed)
+	assert.Equal(t, "group1", group)
+	assert.Equal(t, model.DefaultGroupPermissions{Dir: model.GroupPermission{Read: true}}, permissions)
+}
+
+func testApplyTenant(t *testing.T) {
+	live := model.Tenant{ID: "account1", Alias: "alias1", BucketBlockSize: 100}
+
+	_, changed := model.TenantCreate{AccountID: "account1", EncryptionEnabled: true}.Update(live)
+	assert.False(t, changed)
+
+	update, changed := model.TenantCreate{AccountID: "account1", BucketBlockSize: 200}.Update(live)
+	assert.True(t, changed)
+	assert.Equal(t, model.TenantUpdate{Alias: "alias1", BucketBlockSize: 200}, update)
+}
+
+func testApplyTenantImmutable(t *testing.T) {
+	live := model.Tenant{ID: "account1", EncryptionEnabled: true}
+
+	assert.Empty(t, model.TenantCreate{AccountID: "account1", Alias: "alias1"}.ImmutableChanges(live))
+	assert.Empty(t, model.TenantCreate{AccountID: "account1", EncryptionEnabled: true}.ImmutableChanges(live))
+
+	desired := model.TenantCreate{AccountID: "account1", EncryptionEnabled: true, ComplianceEnabled: true}
+	assert.Equal(t, []string{"is_compliance_enabled"}, desired.ImmutableChanges(live))
+
+	err := desired.CheckImmutable(live)
+	require.ErrorIs(t, err, model.ErrImmutableField)
+	assert.True(t, model.IsConflict(err))
+}
+
+func testApplyAlertPolicy(t *testing.T) {
+	live := model.AlertPolicy{
+		PolicyName: "policy1",
+		CreatedBy:  "USER",
+		Period:     60,
+		Condition:  model.AlertPolicyCondition{ThresholdValue: "1", SeverityType: "WARNING"},
+	}
+
+	_, changed := model.AlertPolicy{PolicyName: "policy1", Period: 60}.Merge(live)
+	assert.False(t, changed)
+
+	merged, changed := model.AlertPolicy{PolicyName: "policy1", CreatedBy: "OTHER",
+		Condition: model.AlertPolicyCondition{ThresholdValue: "2"}}.Merge(live)
+	assert.True(t, changed)
+	assert.Equal(t, "USER", merged.CreatedBy)
+	assert.Equal(t, 60, merged.Period)
+	assert.Equal(t, model.AlertPolicyCondition{ThresholdValue: "2", SeverityType: "WARNING"}, merged.Condition)
+}
@@ -12,6 +12,8 @@
 
 package model
 
+import "strconv"
+
 // RKELoginRequest is a model of login request body, posted to /mgmt/auth/login on RKE platform.
 type RKELoginRequest struct {
 	Username string `json:"username"`
@@ -25,3 +27,31 @@ type RKELoginResponse struct {
 	RefreshToken     string `json:"refresh_token"`
 	RefreshExpiresIn int    `json:"refresh_expires_in"`
 }
+
+// RKEErrorResponse is a model of error response body, received from /mgmt/auth/login on RKE platform.
+type RKEErrorResponse struct {
+	HTTPStatusCode int               `json:"http_status_code"`
+	Messages       []RKEErrorMessage `json:"messages"`
+}
+
+// RKEErrorMessage is a single message of RKEErrorResponse.
+type RKEErrorMessage struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AsError converts the first message of the response into Error.
+func (r RKEErrorResponse) AsError() Error {
+	if len(r.Messages) == 0 {
+		return Error{}
+	}
+
+	code, _ := strconv.ParseInt(r.Messages[0].Code, 10, 64)
+
+	return Error{
+		Code:        code,
+		Description: r.Messages[0].Message,
+	}
+}
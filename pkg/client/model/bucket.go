@@ -30,6 +30,36 @@ type BucketCreate struct {
 	Bucket
 }
 
+// MarshalXML implements the xml.Marshaler interface. encoding/xml inlines the
+// fields of the embedded SearchMetadata, so they are encoded in a
+// search_metadata element as the management API expects.
+func (b BucketCreate) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type bucket Bucket // without the UnmarshalXML method
+
+	v := struct {
+		XMLName xml.Name `xml:"object_bucket_create"`
+
+		bucket
+
+		// MaxKeys, Enabled and Metadata shadow the inlined search metadata
+		// fields and are always empty
+		MaxKeys  int        `xml:"maxKeys,omitempty"`
+		Enabled  bool       `xml:"isEnabled,omitempty"`
+		Metadata []Metadata `xml:"metadata,omitempty"`
+
+		// Search is the search_metadata element
+		Search *SearchMetadata `xml:"search_metadata,omitempty"`
+	}{bucket: bucket(b.Bucket)}
+
+	if b.SearchMetadata.Enabled {
+		v.Search = &b.SearchMetadata
+	}
+
+	start.Name = xml.Name{Local: "object_bucket_create"}
+
+	return e.EncodeElement(v, start)
+}
+
 // BucketQuotaUpdate is the struct of quota updating.
 type BucketQuotaUpdate struct {
 	// XMLName is the name of the xml tag used XML marshalling
@@ -159,11 +189,11 @@ type Bucket struct {
 	// for default group
 	DefaultGroupFileReadPermission bool `json:"default_group_file_read_permission,omitempty" xml:"default_group_file_read_permission,omitempty"`
 
-	// DefaultGroupFileWritePermission is a flag indicating the Execute permission
+	// DefaultGroupFileExecutePermission is a flag indicating the Execute permission
 	// for default group
 	DefaultGroupFileExecutePermission bool `json:"default_group_file_execute_permission,omitempty" xml:"default_group_file_execute_permission,omitempty"`
 
-	// DefaultGroupFileExecutePermission is a flag indicating the Write permission
+	// DefaultGroupFileWritePermission is a flag indicating the Write permission
 	// for default group
 	DefaultGroupFileWritePermission bool `json:"default_group_file_write_permission,omitempty" xml:"default_group_file_write_permission,omitempty"`
 
@@ -171,11 +201,11 @@ type Bucket struct {
 	// for default group
 	DefaultGroupDirReadPermission bool `json:"default_group_dir_read_permission,omitempty" xml:"default_group_dir_read_permission,omitempty"`
 
-	// DefaultGroupDirWritePermission is a flag indicating the Execute permission
+	// DefaultGroupDirExecutePermission is a flag indicating the Execute permission
 	// for default group
 	DefaultGroupDirExecutePermission bool `json:"default_group_dir_execute_permission,omitempty" xml:"default_group_dir_execute_permission,omitempty"`
 
-	// DefaultGroupDirExecutePermission is a flag indicating the Write permission
+	// DefaultGroupDirWritePermission is a flag indicating the Write permission
 	// for default group
 	DefaultGroupDirWritePermission bool `json:"default_group_dir_write_permission,omitempty" xml:"default_group_dir_write_permission,omitempty"`
 
@@ -187,12 +217,50 @@ type Bucket struct {
 	SearchMetadata `json:"search_metadata,omitempty" xml:"search_metadata,omitempty"`
 
 	// MinMaxGovenor enforces minimum and maximum retention for bucket objects
-	MinMaxGovenor `json:"min_max_govenor,omitempty" xml:"min_max_govenor,omitempty"`
+	MinMaxGovenor `json:"min_max_governor,omitempty" xml:"min_max_governor,omitempty"`
 
 	// StoragePolicy is the default storage policy of the bucket
 	StoragePolicy string `json:"storagePolicy,omitempty" xml:"storage_policy,omitempty"`
 }
 
+// UnmarshalXML implements the xml.Unmarshaler interface. encoding/xml inlines
+// the fields of the embedded SearchMetadata and MinMaxGovenor, so the
+// search_metadata and min_max_governor elements of the management API are
+// decoded separately.
+func (b *Bucket) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type bucket Bucket // without the UnmarshalXML method
+
+	var v struct {
+		// XMLName accepts any element, e.g. bucket_info
+		XMLName xml.Name
+
+		bucket
+
+		// Search is the search_metadata element
+		Search *SearchMetadata `xml:"search_metadata"`
+
+		// Governor is the min_max_governor element
+		Governor *MinMaxGovenor `xml:"min_max_governor"`
+	}
+
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	*b = Bucket(v.bucket)
+	b.XMLName = v.XMLName
+
+	if v.Search != nil {
+		b.SearchMetadata = *v.Search
+	}
+
+	if v.Governor != nil {
+		b.MinMaxGovenor = *v.Governor
+	}
+
+	return nil
+}
+
 // BucketList is a list of object storage buckets.
 type BucketList struct {
 	// XMLName is the name of the xml tag used XML marshalling
@@ -0,0 +1,210 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// ErrFSAccessDisabled is returned when updating the default group of a bucket
+// without file-system access enabled.
+var ErrFSAccessDisabled = errors.New("file-system access is not enabled")
+
+// GroupPermission are the POSIX-like permission bits granted to the default
+// group of a bucket.
+type GroupPermission struct {
+	// Read is the read permission
+	Read bool `json:"read"`
+
+	// Write is the write permission
+	Write bool `json:"write"`
+
+	// Execute is the execute permission
+	Execute bool `json:"execute"`
+}
+
+// ParseGroupPermission parses permission bits in symbolic notation, such as
+// "rw-" or "r-x".
+func ParseGroupPermission(s string) (GroupPermission, error) {
+	if len(s) != 3 {
+		return GroupPermission{}, fmt.Errorf("invalid permission %q", s)
+	}
+
+	var p GroupPermission
+
+	for i, bit := range []*bool{&p.Read, &p.Write, &p.Execute} {
+		switch s[i] {
+		case "rwx"[i]:
+			*bit = true
+		case '-':
+		default:
+			return GroupPermission{}, fmt.Errorf("invalid permission %q", s)
+		}
+	}
+
+	return p, nil
+}
+
+// String returns the permission bits in symbolic notation.
+func (p GroupPermission) String() string {
+	b := []byte("---")
+
+	if p.Read {
+		b[0] = 'r'
+	}
+
+	if p.Write {
+		b[1] = 'w'
+	}
+
+	if p.Execute {
+		b[2] = 'x'
+	}
+
+	return string(b)
+}
+
+// Octal returns the permission bits as an octal digit.
+func (p GroupPermission) Octal() int {
+	mode := 0
+
+	if p.Read {
+		mode |= 4
+	}
+
+	if p.Write {
+		mode |= 2
+	}
+
+	if p.Execute {
+		mode |= 1
+	}
+
+	return mode
+}
+
+// DefaultGroupPermissions are the permissions granted to the default group of
+// a bucket on files and directories.
+type DefaultGroupPermissions struct {
+	// File are the permissions on files
+	File GroupPermission `json:"file"`
+
+	// Dir are the permissions on directories
+	Dir GroupPermission `json:"dir"`
+}
+
+// DefaultGroupPermissions returns the permissions granted to the default group
+// of the bucket.
+func (b Bucket) DefaultGroupPermissions() DefaultGroupPermissions {
+	return DefaultGroupPermissions{
+		File: GroupPermission{
+			Read:    b.DefaultGroupFileReadPermission,
+			Write:   b.DefaultGroupFileWritePermission,
+			Execute: b.DefaultGroupFileExecutePermission,
+		},
+		Dir: GroupPermission{
+			Read:    b.DefaultGroupDirReadPermission,
+			Write:   b.DefaultGroupDirWritePermission,
+			Execute: b.DefaultGroupDirExecutePermission,
+		},
+	}
+}
+
+// SetDefaultGroupPermissions sets the permissions granted to the default group
+// of the bucket.
+func (b *Bucket) SetDefaultGroupPermissions(p DefaultGroupPermissions) {
+	b.DefaultGroupFileReadPermission = p.File.Read
+	b.DefaultGroupFileWritePermission = p.File.Write
+	b.DefaultGroupFileExecutePermission = p.File.Execute
+	b.DefaultGroupDirReadPermission = p.Dir.Read
+	b.DefaultGroupDirWritePermission = p.Dir.Write
+	b.DefaultGroupDirExecutePermission = p.Dir.Execute
+}
+
+// BucketDefaultGroupUpdate is the request updating the default group of a
+// bucket and its permissions.
+type BucketDefaultGroupUpdate struct {
+	// XMLName is the name of the xml tag used XML marshalling
+	XMLName xml.Name `json:"-" xml:"default_group_update"`
+
+	// Namespace is the namespace of the bucket
+	Namespace string `json:"namespace" xml:"namespace"`
+
+	// DefaultGroup is the bucket's default group
+	DefaultGroup string `json:"default_group" xml:"default_group"`
+
+	// DefaultGroupFileReadPermission is the read permission on files
+	DefaultGroupFileReadPermission bool `json:"default_group_file_read_permission" xml:"default_group_file_read_permission"`
+
+	// DefaultGroupFileWritePermission is the write permission on files
+	DefaultGroupFileWritePermission bool `json:"default_group_file_write_permission" xml:"default_group_file_write_permission"`
+
+	// DefaultGroupFileExecutePermission is the execute permission on files
+	DefaultGroupFileExecutePermission bool `json:"default_group_file_execute_permission" xml:"default_group_file_execute_permission"`
+
+	// DefaultGroupDirReadPermission is the read permission on directories
+	DefaultGroupDirReadPermission bool `json:"default_group_dir_read_permission" xml:"default_group_dir_read_permission"`
+
+	// DefaultGroupDirWritePermission is the write permission on directories
+	DefaultGroupDirWritePermission bool `json:"default_group_dir_write_permission" xml:"default_group_dir_write_permission"`
+
+	// DefaultGroupDirExecutePermission is the execute permission on directories
+	DefaultGroupDirExecutePermission bool `json:"default_group_dir_execute_permission" xml:"default_group_dir_execute_permission"`
+}
+
+// NewBucketDefaultGroupUpdate returns the request setting the default group
+// of a bucket and its permissions.
+func NewBucketDefaultGroupUpdate(namespace, group string, p DefaultGroupPermissions) BucketDefaultGroupUpdate {
+	return BucketDefaultGroupUpdate{
+		Namespace:                         namespace,
+		DefaultGroup:                      group,
+		DefaultGroupFileReadPermission:    p.File.Read,
+		DefaultGroupFileWritePermission:   p.File.Write,
+		DefaultGroupFileExecutePermission: p.File.Execute,
+		DefaultGroupDirReadPermission:     p.Dir.Read,
+		DefaultGroupDirWritePermission:    p.Dir.Write,
+		DefaultGroupDirExecutePermission:  p.Dir.Execute,
+	}
+}
+
+// Permissions returns the permissions set by the request.
+func (u BucketDefaultGroupUpdate) Permissions() DefaultGroupPermissions {
+	return DefaultGroupPermissions{
+		File: GroupPermission{
+			Read:    u.DefaultGroupFileReadPermission,
+			Write:   u.DefaultGroupFileWritePermission,
+			Execute: u.DefaultGroupFileExecutePermission,
+		},
+		Dir: GroupPermission{
+			Read:    u.DefaultGroupDirReadPermission,
+			Write:   u.DefaultGroupDirWritePermission,
+			Execute: u.DefaultGroupDirExecutePermission,
+		},
+	}
+}
+
+// CheckDefaultGroupUpdate checks that the default group of the bucket can be
+// updated, which requires file-system access.
+func CheckDefaultGroupUpdate(bucket Bucket, group string) error {
+	if !bucket.FSEnabled {
+		return fmt.Errorf("bucket %s: %w", bucket.Name, ErrFSAccessDisabled)
+	}
+
+	if group == "" {
+		return fmt.Errorf("bucket %s: default group is required", bucket.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultGroup(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"ParseGroupPermission": testParseGroupPermission,
+		"BucketConversion":     testDefaultGroupBucketConversion,
+		"Update":               testDefaultGroupUpdate,
+		"Check":                testDefaultGroupCheck,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testParseGroupPermission(t *testing.T) {
+	for s, octal := range map[string]int{"---": 0, "--x": 1, "-w-": 2, "r--": 4, "r-x": 5, "rw-": 6, "rwx": 7} {
+		p, err := model.ParseGroupPermission(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, p.String())
+		assert.Equal(t, octal, p.Octal(), s)
+	}
+
+	for _, s := range []string{"", "rw", "rwxr", "xwr", "RWX"} {
+		_, err := model.ParseGroupPermission(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func testDefaultGroupBucketConversion(t *testing.T) {
+	permissions := model.DefaultGroupPermissions{
+		File: model.GroupPermission{Read: true, Write: true},
+		Dir:  model.GroupPermission{Read: true, Execute: true},
+	}
+
+	bucket := model.Bucket{}
+	bucket.SetDefaultGroupPermissions(permissions)
+
+	assert.True(t, bucket.DefaultGroupFileReadPermission)
+	assert.True(t, bucket.DefaultGroupFileWritePermission)
+	assert.False(t, bucket.DefaultGroupFileExecutePermission)
+	assert.True(t, bucket.DefaultGroupDirReadPermission)
+	assert.False(t, bucket.DefaultGroupDirWritePermission)
+	assert.True(t, bucket.DefaultGroupDirExecutePermission)
+	assert.Equal(t, permissions, bucket.DefaultGroupPermissions())
+}
+
+func testDefaultGroupUpdate(t *testing.T) {
+	permissions := model.DefaultGroupPermissions{Dir: model.GroupPermission{Write: true}}
+	update := model.NewBucketDefaultGroupUpdate("ns", "group", permissions)
+
+	assert.Equal(t, permissions, update.Permissions())
+
+	out, err := xml.Marshal(update)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<default_group_update><namespace>ns</namespace><default_group>group</default_group>`)
+	assert.Contains(t, string(out), `<default_group_file_read_permission>false</default_group_file_read_permission>`)
+	assert.Contains(t, string(out), `<default_group_dir_write_permission>true</default_group_dir_write_permission>`)
+}
+
+func testDefaultGroupCheck(t *testing.T) {
+	assert.NoError(t, model.CheckDefaultGroupUpdate(model.Bucket{Name: "b", FSEnabled: true}, "group"))
+	assert.ErrorIs(t, model.CheckDefaultGroupUpdate(model.Bucket{Name: "b"}, "group"), model.ErrFSAccessDisabled)
+	assert.Error(t, model.CheckDefaultGroupUpdate(model.Bucket{Name: "b", FSEnabled: true}, ""))
+}
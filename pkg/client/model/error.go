@@ -16,6 +16,8 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strings"
 )
 
@@ -76,7 +78,10 @@ func (err Error) Is(target error) bool {
 	return strings.EqualFold(err.Error(), target.Error())
 }
 
-// Error Codes.
+// Error Codes. Only the codes defined since the first release of this
+// package are declared; other errors are classified by the HTTP status of the
+// response. In particular, no code is known for tenants, object users and
+// alert policies that already exist, so IsAlreadyExists does not detect them.
 const (
 	// Request parameter cannot be found.
 	CodeParameterNotFound int64 = 1004
@@ -91,3 +96,99 @@ const (
 	// Bucket already exists.
 	CodeBucketAlreadyExists int64 = 40008
 )
+
+// Errors matched by errors without a management API error code, e.g. IAM
+// errors, to classify them for the predicates below.
+var (
+	// ErrAlreadyExists indicates that the resource already exists.
+	ErrAlreadyExists = errors.New("resource already exists")
+
+	// ErrConflict indicates a conflict with the current state of the resource.
+	ErrConflict = errors.New("conflict")
+)
+
+// httpStatuser is implemented by errors carrying the HTTP status of the
+// response they were decoded from.
+type httpStatuser interface {
+	HTTPStatus() int
+}
+
+// codeOf returns the management API error code and the HTTP status of err, or
+// zero values if err does not carry them.
+func codeOf(err error) (Error, int) {
+	var (
+		apiErr   Error
+		statuser httpStatuser
+		status   int
+	)
+
+	if errors.As(err, &statuser) {
+		status = statuser.HTTPStatus()
+	}
+
+	if !errors.As(err, &apiErr) {
+		var ptr *Error
+		if errors.As(err, &ptr) && ptr != nil {
+			apiErr = *ptr
+		}
+	}
+
+	return apiErr, status
+}
+
+// hasCode reports whether err has one of the codes.
+func hasCode(err Error, codes ...int64) bool {
+	return slices.Contains(codes, err.Code)
+}
+
+// IsNotFound reports whether err indicates that the resource does not exist.
+func IsNotFound(err error) bool {
+	apiErr, status := codeOf(err)
+
+	return status == http.StatusNotFound || hasCode(apiErr, CodeParameterNotFound, CodeResourceNotFound)
+}
+
+// IsAlreadyExists reports whether err indicates that the resource already exists:
+// a bucket (CodeBucketAlreadyExists) or an IAM entity (ErrAlreadyExists).
+// Other resources have no known code for it.
+func IsAlreadyExists(err error) bool {
+	apiErr, _ := codeOf(err)
+
+	return errors.Is(err, ErrAlreadyExists) || hasCode(apiErr, CodeBucketAlreadyExists)
+}
+
+// IsConflict reports whether err indicates a conflict with the current state of
+// the resource, including the resource already existing.
+func IsConflict(err error) bool {
+	_, status := codeOf(err)
+
+	return status == http.StatusConflict || errors.Is(err, ErrConflict) || IsAlreadyExists(err)
+}
+
+// IsQuotaExceeded reports whether err indicates that a limit or quota was exceeded.
+func IsQuotaExceeded(err error) bool {
+	apiErr, _ := codeOf(err)
+
+	return hasCode(apiErr, CodeExceedingLimit)
+}
+
+// IsForbidden reports whether err indicates that the user is not allowed to
+// perform the operation.
+func IsForbidden(err error) bool {
+	_, status := codeOf(err)
+
+	return status == http.StatusForbidden
+}
+
+// IsRetryable reports whether the request returning err may succeed if retried,
+// either because the management API says so or because of the HTTP status.
+func IsRetryable(err error) bool {
+	apiErr, status := codeOf(err)
+
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return apiErr.Retryable
+}
@@ -14,6 +14,9 @@ package model_test
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/dell/goobjectscale/pkg/client/model"
@@ -25,6 +28,7 @@ func TestError(t *testing.T) {
 		"Error":      testErrorError,
 		"StatusCode": testErrorStatusCode,
 		"Is":         testErrorIs,
+		"Predicates": testErrorPredicates,
 	} {
 		t.Run(scenario, fn)
 	}
@@ -188,3 +192,102 @@ func testErrorIs(t *testing.T) {
 		})
 	}
 }
+
+// statusError is an error carrying an HTTP status, wrapping a model.Error.
+type statusError struct {
+	err    model.Error
+	status int
+}
+
+func (e statusError) Error() string   { return e.err.Error() }
+func (e statusError) Unwrap() error   { return e.err }
+func (e statusError) HTTPStatus() int { return e.status }
+
+func testErrorPredicates(t *testing.T) {
+	type predicate func(error) bool
+
+	predicates := map[string]predicate{
+		"IsNotFound":      model.IsNotFound,
+		"IsAlreadyExists": model.IsAlreadyExists,
+		"IsConflict":      model.IsConflict,
+		"IsQuotaExceeded": model.IsQuotaExceeded,
+		"IsForbidden":     model.IsForbidden,
+		"IsRetryable":     model.IsRetryable,
+	}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "resource not found",
+			err:      model.Error{Code: model.CodeResourceNotFound},
+			expected: []string{"IsNotFound"},
+		},
+		{
+			name:     "pointer and wrapped",
+			err:      fmt.Errorf("get: %w", &model.Error{Code: model.CodeParameterNotFound}),
+			expected: []string{"IsNotFound"},
+		},
+		{
+			name:     "bucket already exists",
+			err:      model.Error{Code: model.CodeBucketAlreadyExists},
+			expected: []string{"IsAlreadyExists", "IsConflict"},
+		},
+		{
+			name:     "already exists without code",
+			err:      fmt.Errorf("create: %w", model.ErrAlreadyExists),
+			expected: []string{"IsAlreadyExists", "IsConflict"},
+		},
+		{
+			name:     "quota exceeded",
+			err:      model.Error{Code: model.CodeExceedingLimit},
+			expected: []string{"IsQuotaExceeded"},
+		},
+		{
+			name:     "retryable flag",
+			err:      model.Error{Code: model.CodeInternalException, Retryable: true},
+			expected: []string{"IsRetryable"},
+		},
+		{
+			name:     "HTTP 404 with unknown code",
+			err:      statusError{status: http.StatusNotFound},
+			expected: []string{"IsNotFound"},
+		},
+		{
+			name:     "HTTP 403 with unknown code",
+			err:      statusError{status: http.StatusForbidden},
+			expected: []string{"IsForbidden"},
+		},
+		{
+			name:     "HTTP 409 with unknown code",
+			err:      statusError{status: http.StatusConflict},
+			expected: []string{"IsConflict"},
+		},
+		{
+			name:     "HTTP 503 with unknown code",
+			err:      statusError{status: http.StatusServiceUnavailable},
+			expected: []string{"IsRetryable"},
+		},
+		{
+			name: "internal exception",
+			err:  model.Error{Code: model.CodeInternalException},
+		},
+		{
+			name: "other error",
+			err:  errors.New("not found"),
+		},
+		{
+			name: "nil",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for name, fn := range predicates {
+				assert.Equal(t, slices.Contains(tc.expected, name), fn(tc.err), name)
+			}
+		})
+	}
+}
@@ -21,6 +21,12 @@ type BlobUser struct {
 // ObjectUserList contains an array of object users.
 type ObjectUserList struct {
 	BlobUser []BlobUser `json:"blobuser"`
+
+	// MaxUsers is the maximum number of users requested in the listing
+	MaxUsers int `json:"MaxUsers,omitempty"`
+
+	// NextMarker is a reference object to receive the next set of users
+	NextMarker string `json:"NextMarker,omitempty"`
 }
 
 // ObjectUserInfo contains information about an object user.
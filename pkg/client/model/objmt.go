@@ -740,3 +740,15 @@ type StoreReplicationThroughputRto struct {
 	// ReplicatedFailedDelta metrics of failed replicated data in object store per storage classes
 	ReplicatedFailedDelta []StorageClassBasedCountSize `xml:"replicate_failed_delta>storage_class_counts,omitempty"`
 }
+
+// ObjectCount returns the number of user objects in the bucket, summed over all
+// the storage classes.
+func (b BucketBillingInfo) ObjectCount() int64 {
+	var count int64
+
+	for _, metric := range b.TotalUserObjectMetric {
+		count += metric.Counts
+	}
+
+	return count
+}
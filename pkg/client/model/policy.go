@@ -0,0 +1,768 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PolicyVersion is the version of the policy language.
+const PolicyVersion = "2012-10-17"
+
+// PolicyEffect is the effect of a policy statement.
+type PolicyEffect string
+
+// Policy statement effects.
+const (
+	EffectAllow PolicyEffect = "Allow"
+	EffectDeny  PolicyEffect = "Deny"
+)
+
+// S3ARNPrefix is the prefix of the ARNs of buckets and objects.
+const S3ARNPrefix = "arn:aws:s3:::"
+
+// ErrInvalidPolicy is returned when a bucket policy does not pass validation.
+var ErrInvalidPolicy = errors.New("invalid bucket policy")
+
+// SupportedBucketPolicyActions are the actions ObjectScale supports in bucket
+// policies.
+var SupportedBucketPolicyActions = []string{
+	// object operations
+	"s3:GetObject",
+	"s3:GetObjectVersion",
+	"s3:PutObject",
+	"s3:DeleteObject",
+	"s3:DeleteObjectVersion",
+	"s3:GetObjectAcl",
+	"s3:GetObjectVersionAcl",
+	"s3:PutObjectAcl",
+	"s3:PutObjectVersionAcl",
+	"s3:GetObjectTagging",
+	"s3:GetObjectVersionTagging",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionTagging",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersionTagging",
+	"s3:GetObjectRetention",
+	"s3:PutObjectRetention",
+	"s3:GetObjectLegalHold",
+	"s3:PutObjectLegalHold",
+	"s3:BypassGovernanceRetention",
+	"s3:ListMultipartUploadParts",
+	"s3:AbortMultipartUpload",
+	// bucket operations
+	"s3:ListBucket",
+	"s3:ListBucketVersions",
+	"s3:ListBucketMultipartUploads",
+	"s3:DeleteBucket",
+	"s3:GetBucketAcl",
+	"s3:PutBucketAcl",
+	"s3:GetBucketCORS",
+	"s3:PutBucketCORS",
+	"s3:GetBucketPolicy",
+	"s3:PutBucketPolicy",
+	"s3:DeleteBucketPolicy",
+	"s3:GetBucketVersioning",
+	"s3:PutBucketVersioning",
+	"s3:GetBucketTagging",
+	"s3:PutBucketTagging",
+	"s3:GetBucketNotification",
+	"s3:PutBucketNotification",
+	"s3:GetLifecycleConfiguration",
+	"s3:PutLifecycleConfiguration",
+	"s3:GetReplicationConfiguration",
+	"s3:PutReplicationConfiguration",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:PutBucketObjectLockConfiguration",
+}
+
+// conditionOperators are the supported condition operators, without the
+// IfExists suffix and the set operator prefixes.
+var conditionOperators = []string{
+	"StringEquals", "StringNotEquals", "StringEqualsIgnoreCase", "StringNotEqualsIgnoreCase",
+	"StringLike", "StringNotLike",
+	"NumericEquals", "NumericNotEquals", "NumericLessThan", "NumericLessThanEquals",
+	"NumericGreaterThan", "NumericGreaterThanEquals",
+	"DateEquals", "DateNotEquals", "DateLessThan", "DateLessThanEquals",
+	"DateGreaterThan", "DateGreaterThanEquals",
+	"Bool", "IpAddress", "NotIpAddress", "ArnEquals", "ArnNotEquals", "ArnLike", "ArnNotLike",
+	"Null",
+}
+
+// BucketARN returns the ARN of the bucket.
+func BucketARN(bucket string) string {
+	return S3ARNPrefix + bucket
+}
+
+// ObjectsARN returns the ARN matching all the objects in the bucket.
+func ObjectsARN(bucket string) string {
+	return S3ARNPrefix + bucket + "/*"
+}
+
+// StringList is a list of strings. In JSON it is either a single string or an
+// array of strings; numbers and booleans are accepted as strings.
+type StringList []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *StringList) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+	} else {
+		raw = []json.RawMessage{b}
+	}
+
+	list := make(StringList, 0, len(raw))
+
+	for _, r := range raw {
+		var v interface{}
+		if err := json.Unmarshal(r, &v); err != nil {
+			return err
+		}
+
+		switch v := v.(type) {
+		case string:
+			list = append(list, v)
+		case bool:
+			list = append(list, strconv.FormatBool(v))
+		case float64:
+			list = append(list, string(bytes.TrimSpace(r)))
+		default:
+			return fmt.Errorf("unexpected %T in string list", v)
+		}
+	}
+
+	*l = list
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. A single string is
+// marshalled as a string.
+func (l StringList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+
+	return json.Marshal([]string(l))
+}
+
+// PolicyPrincipal is the principal of a policy statement: either any principal
+// ("*") or lists of principals by principal type.
+type PolicyPrincipal struct {
+	// Any is true for the "*" principal
+	Any bool
+
+	// AWS are the user and account principals
+	AWS StringList
+
+	// Federated are the web identity and SAML providers
+	Federated StringList
+
+	// Service are the service principals
+	Service StringList
+
+	// CanonicalUser are the canonical user IDs
+	CanonicalUser StringList
+
+	// Other are the principals of any other type, by type, kept so that the
+	// principal is written back unchanged
+	Other map[string]StringList
+}
+
+// Principal types.
+const (
+	PrincipalAWS           = "AWS"
+	PrincipalFederated     = "Federated"
+	PrincipalService       = "Service"
+	PrincipalCanonicalUser = "CanonicalUser"
+)
+
+// IsEmpty reports whether the principal matches no principal.
+func (p PolicyPrincipal) IsEmpty() bool {
+	return !p.Any && len(p.Types()) == 0
+}
+
+// Types returns the principals by type, without the empty lists.
+func (p PolicyPrincipal) Types() map[string]StringList {
+	types := make(map[string]StringList, len(p.Other)+4) //nolint:gomnd
+
+	for t, list := range p.Other {
+		if len(list) != 0 {
+			types[t] = list
+		}
+	}
+
+	for t, list := range map[string]StringList{
+		PrincipalAWS:           p.AWS,
+		PrincipalFederated:     p.Federated,
+		PrincipalService:       p.Service,
+		PrincipalCanonicalUser: p.CanonicalUser,
+	} {
+		if len(list) != 0 {
+			types[t] = list
+		}
+	}
+
+	return types
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (p *PolicyPrincipal) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "*" {
+			*p = PolicyPrincipal{Any: true}
+		} else {
+			*p = PolicyPrincipal{AWS: StringList{s}}
+		}
+
+		return nil
+	}
+
+	var types map[string]StringList
+	if err := json.Unmarshal(b, &types); err != nil {
+		return err
+	}
+
+	*p = PolicyPrincipal{}
+
+	for t, list := range types {
+		switch t {
+		case PrincipalAWS:
+			p.AWS = list
+		case PrincipalFederated:
+			p.Federated = list
+		case PrincipalService:
+			p.Service = list
+		case PrincipalCanonicalUser:
+			p.CanonicalUser = list
+		default:
+			if p.Other == nil {
+				p.Other = make(map[string]StringList)
+			}
+
+			p.Other[t] = list
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p PolicyPrincipal) MarshalJSON() ([]byte, error) {
+	if p.Any {
+		return json.Marshal("*")
+	}
+
+	return json.Marshal(p.Types())
+}
+
+// PolicyCondition are the condition values by condition key by operator, e.g.
+// {"StringEquals": {"s3:prefix": ["home/"]}}.
+type PolicyCondition map[string]map[string]StringList
+
+// PolicyStatement is a single statement of a bucket policy.
+type PolicyStatement struct {
+	// Sid is the optional statement identifier
+	Sid string `json:"Sid,omitempty"`
+
+	// Effect is whether the statement allows or denies access
+	Effect PolicyEffect `json:"Effect"`
+
+	// Principal is the user or account the statement applies to
+	Principal *PolicyPrincipal `json:"Principal,omitempty"`
+
+	// NotPrincipal is the user or account the statement does not apply to
+	NotPrincipal *PolicyPrincipal `json:"NotPrincipal,omitempty"`
+
+	// Action are the actions the statement applies to
+	Action StringList `json:"Action,omitempty"`
+
+	// NotAction are the actions the statement does not apply to
+	NotAction StringList `json:"NotAction,omitempty"`
+
+	// Resource are the buckets and objects the statement applies to
+	Resource StringList `json:"Resource,omitempty"`
+
+	// NotResource are the buckets and objects the statement does not apply to
+	NotResource StringList `json:"NotResource,omitempty"`
+
+	// Condition are the conditions under which the statement applies
+	Condition PolicyCondition `json:"Condition,omitempty"`
+}
+
+// BucketPolicy is a bucket policy document.
+type BucketPolicy struct {
+	// Version is the version of the policy language
+	Version string `json:"Version,omitempty"`
+
+	// ID is the optional policy identifier
+	ID string `json:"Id,omitempty"`
+
+	// Statement are the statements of the policy
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. A single statement
+// is accepted in place of an array of statements.
+func (p *BucketPolicy) UnmarshalJSON(b []byte) error {
+	type policy BucketPolicy
+
+	var v struct {
+		policy
+		Statement json.RawMessage `json:"Statement"`
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*p = BucketPolicy(v.policy)
+	p.Statement = nil
+
+	switch raw := bytes.TrimSpace(v.Statement); {
+	case len(raw) == 0, bytes.Equal(raw, []byte("null")):
+	case raw[0] == '[':
+		if err := json.Unmarshal(raw, &p.Statement); err != nil {
+			return err
+		}
+	default:
+		var s PolicyStatement
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+
+		p.Statement = []PolicyStatement{s}
+	}
+
+	return nil
+}
+
+// ParseBucketPolicy parses a bucket policy document. An empty document results
+// in a policy without statements.
+func ParseBucketPolicy(doc string) (*BucketPolicy, error) {
+	policy := &BucketPolicy{}
+
+	if strings.TrimSpace(doc) == "" {
+		return policy, nil
+	}
+
+	if err := json.Unmarshal([]byte(doc), policy); err != nil {
+		return nil, fmt.Errorf("parse bucket policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// String returns the policy document as JSON.
+func (p BucketPolicy) String() string {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// Validate checks the structure of the policy, and that it uses only the
+// actions and condition operators supported by ObjectScale. All problems are
+// reported, and the returned error matches ErrInvalidPolicy.
+func (p BucketPolicy) Validate() error {
+	var errs []error
+
+	if p.Version != "" && p.Version != PolicyVersion {
+		errs = append(errs, fmt.Errorf("unsupported version %q", p.Version))
+	}
+
+	if len(p.Statement) == 0 {
+		errs = append(errs, errors.New("no statements"))
+	}
+
+	for i, s := range p.Statement {
+		for _, err := range s.validate() {
+			errs = append(errs, fmt.Errorf("statement %d: %w", i, err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidPolicy, errors.Join(errs...))
+}
+
+// validate returns the problems found in the statement.
+func (s PolicyStatement) validate() []error {
+	var errs []error
+
+	if s.Effect != EffectAllow && s.Effect != EffectDeny {
+		errs = append(errs, fmt.Errorf("invalid effect %q", s.Effect))
+	}
+
+	switch {
+	case s.Principal != nil && s.NotPrincipal != nil:
+		errs = append(errs, errors.New("both Principal and NotPrincipal set"))
+	case s.Principal != nil && s.Principal.IsEmpty(), s.NotPrincipal != nil && s.NotPrincipal.IsEmpty(),
+		s.Principal == nil && s.NotPrincipal == nil:
+		errs = append(errs, errors.New("no principal"))
+	}
+
+	switch {
+	case len(s.Action) == 0 && len(s.NotAction) == 0:
+		errs = append(errs, errors.New("no action"))
+	case len(s.Action) != 0 && len(s.NotAction) != 0:
+		errs = append(errs, errors.New("both Action and NotAction set"))
+	}
+
+	for _, action := range append(slices.Clone(s.Action), s.NotAction...) {
+		if !supportedAction(action) {
+			errs = append(errs, fmt.Errorf("unsupported action %q", action))
+		}
+	}
+
+	switch {
+	case len(s.Resource) == 0 && len(s.NotResource) == 0:
+		errs = append(errs, errors.New("no resource"))
+	case len(s.Resource) != 0 && len(s.NotResource) != 0:
+		errs = append(errs, errors.New("both Resource and NotResource set"))
+	}
+
+	for _, resource := range append(slices.Clone(s.Resource), s.NotResource...) {
+		if resource != "*" && !strings.HasPrefix(resource, S3ARNPrefix) {
+			errs = append(errs, fmt.Errorf("invalid resource %q", resource))
+		}
+	}
+
+	for operator, values := range s.Condition {
+		if !supportedOperator(operator) {
+			errs = append(errs, fmt.Errorf("unsupported condition operator %q", operator))
+		}
+
+		if len(values) == 0 {
+			errs = append(errs, fmt.Errorf("empty condition %q", operator))
+		}
+	}
+
+	return errs
+}
+
+// supportedAction reports whether the action, possibly with wildcards, matches
+// at least one supported action.
+func supportedAction(action string) bool {
+	if action == "*" || action == "s3:*" {
+		return true
+	}
+
+	for _, supported := range SupportedBucketPolicyActions {
+		if ok, _ := path.Match(strings.ToLower(action), strings.ToLower(supported)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// supportedOperator reports whether the condition operator is supported.
+func supportedOperator(operator string) bool {
+	operator = strings.TrimPrefix(operator, "ForAnyValue:")
+	operator = strings.TrimPrefix(operator, "ForAllValues:")
+	operator = strings.TrimSuffix(operator, "IfExists")
+
+	return slices.Contains(conditionOperators, operator)
+}
+
+// BucketPolicyBuilder builds a bucket policy statement by statement.
+type BucketPolicyBuilder struct {
+	policy BucketPolicy
+	errs   []error
+}
+
+// NewBucketPolicyBuilder returns a builder of a policy with the current version.
+func NewBucketPolicyBuilder() *BucketPolicyBuilder {
+	return &BucketPolicyBuilder{policy: BucketPolicy{Version: PolicyVersion}}
+}
+
+// ID sets the policy identifier.
+func (b *BucketPolicyBuilder) ID(id string) *BucketPolicyBuilder {
+	b.policy.ID = id
+
+	return b
+}
+
+// Allow starts a new statement allowing access.
+func (b *BucketPolicyBuilder) Allow(sid string) *BucketPolicyBuilder {
+	return b.statement(sid, EffectAllow)
+}
+
+// Deny starts a new statement denying access.
+func (b *BucketPolicyBuilder) Deny(sid string) *BucketPolicyBuilder {
+	return b.statement(sid, EffectDeny)
+}
+
+func (b *BucketPolicyBuilder) statement(sid string, effect PolicyEffect) *BucketPolicyBuilder {
+	b.policy.Statement = append(b.policy.Statement, PolicyStatement{Sid: sid, Effect: effect})
+
+	return b
+}
+
+// current returns the current statement, or nil if no statement was started.
+func (b *BucketPolicyBuilder) current(method string) *PolicyStatement {
+	if len(b.policy.Statement) == 0 {
+		b.errs = append(b.errs, fmt.Errorf("%s called before Allow or Deny", method))
+
+		return nil
+	}
+
+	return &b.policy.Statement[len(b.policy.Statement)-1]
+}
+
+// Principals adds principals to the current statement.
+func (b *BucketPolicyBuilder) Principals(principals ...string) *BucketPolicyBuilder {
+	if s := b.current("Principals"); s != nil {
+		if s.Principal == nil {
+			s.Principal = &PolicyPrincipal{}
+		}
+
+		s.Principal.AWS = append(s.Principal.AWS, principals...)
+	}
+
+	return b
+}
+
+// AnyPrincipal makes the current statement apply to any principal.
+func (b *BucketPolicyBuilder) AnyPrincipal() *BucketPolicyBuilder {
+	if s := b.current("AnyPrincipal"); s != nil {
+		s.Principal = &PolicyPrincipal{Any: true}
+	}
+
+	return b
+}
+
+// Actions adds actions to the current statement.
+func (b *BucketPolicyBuilder) Actions(actions ...string) *BucketPolicyBuilder {
+	if s := b.current("Actions"); s != nil {
+		s.Action = append(s.Action, actions...)
+	}
+
+	return b
+}
+
+// Resources adds resources to the current statement.
+func (b *BucketPolicyBuilder) Resources(resources ...string) *BucketPolicyBuilder {
+	if s := b.current("Resources"); s != nil {
+		s.Resource = append(s.Resource, resources...)
+	}
+
+	return b
+}
+
+// Condition adds a condition to the current statement.
+func (b *BucketPolicyBuilder) Condition(operator, key string, values ...string) *BucketPolicyBuilder {
+	if s := b.current("Condition"); s != nil {
+		if s.Condition == nil {
+			s.Condition = make(PolicyCondition)
+		}
+
+		if s.Condition[operator] == nil {
+			s.Condition[operator] = make(map[string]StringList)
+		}
+
+		s.Condition[operator][key] = append(s.Condition[operator][key], values...)
+	}
+
+	return b
+}
+
+// Build validates and returns the policy.
+func (b *BucketPolicyBuilder) Build() (*BucketPolicy, error) {
+	if len(b.errs) != 0 {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, errors.Join(b.errs...))
+	}
+
+	if err := b.policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy := b.policy
+
+	return &policy, nil
+}
+
+// AccessLevel is the level of access to a bucket granted to a principal.
+type AccessLevel string
+
+// Access levels.
+const (
+	AccessRead      AccessLevel = "Read"
+	AccessWrite     AccessLevel = "Write"
+	AccessReadWrite AccessLevel = "ReadWrite"
+)
+
+// AccessLevels are all the access levels.
+var AccessLevels = []AccessLevel{AccessRead, AccessWrite, AccessReadWrite}
+
+var (
+	readActions = []string{
+		"s3:GetObject",
+		"s3:GetObjectVersion",
+		"s3:ListBucket",
+		"s3:ListBucketVersions",
+	}
+	writeActions = []string{
+		"s3:PutObject",
+		"s3:DeleteObject",
+		"s3:DeleteObjectVersion",
+		"s3:AbortMultipartUpload",
+		"s3:ListMultipartUploadParts",
+		"s3:ListBucketMultipartUploads",
+	}
+)
+
+// Actions returns the actions allowed by the access level, or nil for an
+// unknown access level.
+func (l AccessLevel) Actions() []string {
+	switch l {
+	case AccessRead:
+		return slices.Clone(readActions)
+	case AccessWrite:
+		return slices.Clone(writeActions)
+	case AccessReadWrite:
+		return append(slices.Clone(readActions), writeActions...)
+	}
+
+	return nil
+}
+
+// Sid returns the identifier of the statement holding the principals granted
+// the access level to the bucket.
+func (l AccessLevel) Sid(bucket string) string {
+	return fmt.Sprintf("%s-%s", bucket, l)
+}
+
+// GrantAccess grants the principal the access level to the bucket. The
+// principal is added to the statement identified by the Sid of the access
+// level, which is created if missing, and removed from the statements of the
+// other access levels. The effect, actions and resources of an existing
+// statement of the access level are reset to those of the level, and its
+// NotPrincipal, NotAction and NotResource are removed; its principals and
+// conditions are kept. Other statements are preserved. It reports whether the
+// policy was changed.
+func (p *BucketPolicy) GrantAccess(bucket, principal string, level AccessLevel) (bool, error) {
+	actions := level.Actions()
+	if actions == nil {
+		return false, fmt.Errorf("%w: unknown access level %q", ErrInvalidPolicy, level)
+	}
+
+	changed := false
+
+	for _, other := range AccessLevels {
+		if other != level && p.removePrincipal(other.Sid(bucket), principal) {
+			changed = true
+		}
+	}
+
+	sid := level.Sid(bucket)
+	resources := StringList{BucketARN(bucket), ObjectsARN(bucket)}
+
+	i := slices.IndexFunc(p.Statement, func(s PolicyStatement) bool { return s.Sid == sid })
+	if i < 0 {
+		if p.Version == "" {
+			p.Version = PolicyVersion
+		}
+
+		p.Statement = append(p.Statement, PolicyStatement{
+			Sid:       sid,
+			Effect:    EffectAllow,
+			Principal: &PolicyPrincipal{AWS: StringList{principal}},
+			Action:    actions,
+			Resource:  resources,
+		})
+
+		return true, nil
+	}
+
+	s := &p.Statement[i]
+
+	if s.Effect != EffectAllow || !slices.Equal(s.Action, actions) || !slices.Equal(s.Resource, resources) ||
+		s.NotPrincipal != nil || s.NotAction != nil || s.NotResource != nil {
+		s.Effect = EffectAllow
+		s.Action = actions
+		s.Resource = resources
+		s.NotPrincipal = nil
+		s.NotAction = nil
+		s.NotResource = nil
+		changed = true
+	}
+
+	if s.Principal == nil {
+		s.Principal = &PolicyPrincipal{}
+	}
+
+	if !s.Principal.Any && !slices.Contains(s.Principal.AWS, principal) {
+		s.Principal.AWS = append(s.Principal.AWS, principal)
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// RevokeAccess removes the principal from the statements of all the access
+// levels to the bucket, and removes the statements left without principals.
+// Other statements are preserved. It reports whether the policy was changed.
+func (p *BucketPolicy) RevokeAccess(bucket, principal string) bool {
+	changed := false
+
+	for _, level := range AccessLevels {
+		if p.removePrincipal(level.Sid(bucket), principal) {
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// removePrincipal removes the principal from the statement with the given Sid,
+// and removes the statement if no principals are left. It reports whether the
+// policy was changed.
+func (p *BucketPolicy) removePrincipal(sid, principal string) bool {
+	i := slices.IndexFunc(p.Statement, func(s PolicyStatement) bool { return s.Sid == sid })
+	if i < 0 || p.Statement[i].Principal == nil {
+		return false
+	}
+
+	s := &p.Statement[i]
+
+	n := len(s.Principal.AWS)
+	s.Principal.AWS = slices.DeleteFunc(s.Principal.AWS, func(aws string) bool { return aws == principal })
+
+	if len(s.Principal.AWS) == n {
+		return false
+	}
+
+	if s.Principal.IsEmpty() {
+		p.Statement = slices.Delete(p.Statement, i, i+1)
+	}
+
+	return true
+}
@@ -0,0 +1,271 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketPolicy(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Parse":          testBucketPolicyParse,
+		"ParseEmpty":     testBucketPolicyParseEmpty,
+		"ParseSingle":    testBucketPolicyParseSingle,
+		"Marshal":        testBucketPolicyMarshal,
+		"RoundTrip":      testBucketPolicyRoundTrip,
+		"Validate":       testBucketPolicyValidate,
+		"Builder":        testBucketPolicyBuilder,
+		"BuilderInvalid": testBucketPolicyBuilderInvalid,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testBucketPolicyParse(t *testing.T) {
+	policy, err := model.ParseBucketPolicy(`{
+		"Version": "2012-10-17",
+		"Id": "policy1",
+		"Statement": [{
+			"Sid": "read",
+			"Effect": "Allow",
+			"Principal": {"AWS": ["urn:osc:iam::ns1:user/user1", "urn:osc:iam::ns1:user/user2"]},
+			"Action": ["s3:GetObject", "s3:ListBucket"],
+			"Resource": ["arn:aws:s3:::bucket1", "arn:aws:s3:::bucket1/*"],
+			"Condition": {"NumericLessThan": {"s3:max-keys": 10}, "Bool": {"aws:SecureTransport": true}}
+		}, {
+			"Effect": "Deny",
+			"Principal": "*",
+			"Action": "s3:DeleteObject",
+			"Resource": "arn:aws:s3:::bucket1/*"
+		}]
+	}`)
+	require.NoError(t, err)
+	require.NoError(t, policy.Validate())
+
+	assert.Equal(t, "policy1", policy.ID)
+	require.Len(t, policy.Statement, 2)
+
+	read := policy.Statement[0]
+	assert.Equal(t, model.EffectAllow, read.Effect)
+	assert.Equal(t, model.StringList{"urn:osc:iam::ns1:user/user1", "urn:osc:iam::ns1:user/user2"}, read.Principal.AWS)
+	assert.Equal(t, model.StringList{"s3:GetObject", "s3:ListBucket"}, read.Action)
+	assert.Equal(t, model.StringList{"10"}, read.Condition["NumericLessThan"]["s3:max-keys"])
+	assert.Equal(t, model.StringList{"true"}, read.Condition["Bool"]["aws:SecureTransport"])
+
+	deny := policy.Statement[1]
+	assert.Equal(t, model.EffectDeny, deny.Effect)
+	assert.True(t, deny.Principal.Any)
+	assert.Equal(t, model.StringList{"s3:DeleteObject"}, deny.Action)
+	assert.Equal(t, model.StringList{model.ObjectsARN("bucket1")}, deny.Resource)
+}
+
+func testBucketPolicyParseEmpty(t *testing.T) {
+	policy, err := model.ParseBucketPolicy("")
+	require.NoError(t, err)
+	assert.Empty(t, policy.Statement)
+
+	_, err = model.ParseBucketPolicy("{")
+	require.Error(t, err)
+
+	_, err = model.ParseBucketPolicy(`{"Statement": [{"Action": {"a": "b"}}]}`)
+	require.Error(t, err)
+}
+
+func testBucketPolicyParseSingle(t *testing.T) {
+	policy, err := model.ParseBucketPolicy(`{"Statement": {
+		"Effect": "Allow",
+		"Principal": "usr",
+		"Action": "s3:*",
+		"Resource": "*"
+	}}`)
+	require.NoError(t, err)
+	require.Len(t, policy.Statement, 1)
+	assert.Equal(t, model.StringList{"usr"}, policy.Statement[0].Principal.AWS)
+	require.NoError(t, policy.Validate())
+}
+
+func testBucketPolicyMarshal(t *testing.T) {
+	policy := model.BucketPolicy{
+		Version: model.PolicyVersion,
+		Statement: []model.PolicyStatement{{
+			Effect:    model.EffectAllow,
+			Principal: &model.PolicyPrincipal{Any: true},
+			Action:    model.StringList{"s3:GetObject"},
+			Resource:  model.StringList{model.ObjectsARN("bucket1"), model.BucketARN("bucket1")},
+		}},
+	}
+
+	assert.JSONEq(t, `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:GetObject",
+			"Resource": ["arn:aws:s3:::bucket1/*", "arn:aws:s3:::bucket1"]
+		}]
+	}`, policy.String())
+
+	var parsed model.BucketPolicy
+	require.NoError(t, json.Unmarshal([]byte(policy.String()), &parsed))
+	assert.Equal(t, policy, parsed)
+}
+
+func testBucketPolicyRoundTrip(t *testing.T) {
+	doc := `{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Sid": "services",
+			"Effect": "Allow",
+			"Principal": {
+				"Service": ["logging.s3.amazonaws.com", "delivery.logs.amazonaws.com"],
+				"Federated": "cognito-identity.amazonaws.com",
+				"CanonicalUser": "79a59df900b949e55d96a1e698fbacedfd6e09d98eacf8f8d5218e7cd47ef2be",
+				"Custom": "custom-principal"
+			},
+			"Action": "s3:PutObject",
+			"Resource": "arn:aws:s3:::bucket1/*"
+		}, {
+			"Sid": "others",
+			"Effect": "Deny",
+			"NotPrincipal": {"AWS": "urn:osc:iam::ns1:user/admin"},
+			"Action": "s3:DeleteBucket",
+			"Resource": "arn:aws:s3:::bucket1"
+		}]
+	}`
+
+	policy, err := model.ParseBucketPolicy(doc)
+	require.NoError(t, err)
+	require.NoError(t, policy.Validate())
+	assert.JSONEq(t, doc, policy.String())
+
+	services := policy.Statement[0].Principal
+	assert.Equal(t, model.StringList{"logging.s3.amazonaws.com", "delivery.logs.amazonaws.com"}, services.Service)
+	assert.Equal(t, model.StringList{"cognito-identity.amazonaws.com"}, services.Federated)
+	assert.Len(t, services.CanonicalUser, 1)
+	assert.Equal(t, map[string]model.StringList{"Custom": {"custom-principal"}}, services.Other)
+	assert.Empty(t, services.AWS)
+
+	others := policy.Statement[1]
+	assert.Nil(t, others.Principal)
+	assert.Equal(t, model.StringList{"urn:osc:iam::ns1:user/admin"}, others.NotPrincipal.AWS)
+}
+
+func testBucketPolicyValidate(t *testing.T) {
+	valid := func() model.PolicyStatement {
+		return model.PolicyStatement{
+			Effect:    model.EffectAllow,
+			Principal: &model.PolicyPrincipal{AWS: model.StringList{"user1"}},
+			Action:    model.StringList{"s3:Get*"},
+			Resource:  model.StringList{model.BucketARN("bucket1")},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		modify func(s *model.PolicyStatement)
+		valid  bool
+	}{
+		"valid":           {modify: func(_ *model.PolicyStatement) {}, valid: true},
+		"wildcard action": {modify: func(s *model.PolicyStatement) { s.Action = model.StringList{"s3:*"} }, valid: true},
+		"not action":      {modify: func(s *model.PolicyStatement) { s.Action, s.NotAction = nil, model.StringList{"s3:PutObject"} }, valid: true},
+		"if exists": {modify: func(s *model.PolicyStatement) {
+			s.Condition = model.PolicyCondition{"StringLikeIfExists": {"s3:prefix": {"a/"}}}
+		}, valid: true},
+		"for any value": {modify: func(s *model.PolicyStatement) {
+			s.Condition = model.PolicyCondition{"ForAnyValue:StringEquals": {"k": {"v"}}}
+		}, valid: true},
+		"invalid effect":  {modify: func(s *model.PolicyStatement) { s.Effect = "Maybe" }},
+		"no principal":    {modify: func(s *model.PolicyStatement) { s.Principal = nil }},
+		"empty principal": {modify: func(s *model.PolicyStatement) { s.Principal = &model.PolicyPrincipal{} }},
+		"service principal": {
+			modify: func(s *model.PolicyStatement) {
+				s.Principal = &model.PolicyPrincipal{Service: model.StringList{"logging.s3.amazonaws.com"}}
+			},
+			valid: true,
+		},
+		"not principal": {
+			modify: func(s *model.PolicyStatement) { s.Principal, s.NotPrincipal = nil, s.Principal },
+			valid:  true,
+		},
+		"both principals":  {modify: func(s *model.PolicyStatement) { s.NotPrincipal = s.Principal }},
+		"no action":        {modify: func(s *model.PolicyStatement) { s.Action = nil }},
+		"both actions":     {modify: func(s *model.PolicyStatement) { s.NotAction = model.StringList{"s3:PutObject"} }},
+		"unsupported":      {modify: func(s *model.PolicyStatement) { s.Action = model.StringList{"s3:PutBucketWebsite"} }},
+		"no resource":      {modify: func(s *model.PolicyStatement) { s.Resource = nil }},
+		"invalid resource": {modify: func(s *model.PolicyStatement) { s.Resource = model.StringList{"bucket1"} }},
+		"not resource": {
+			modify: func(s *model.PolicyStatement) {
+				s.Resource, s.NotResource = nil, model.StringList{model.BucketARN("b2")}
+			},
+			valid: true,
+		},
+		"both resources":   {modify: func(s *model.PolicyStatement) { s.NotResource = model.StringList{model.BucketARN("b2")} }},
+		"invalid operator": {modify: func(s *model.PolicyStatement) { s.Condition = model.PolicyCondition{"Matches": {"k": {"v"}}} }},
+		"empty condition":  {modify: func(s *model.PolicyStatement) { s.Condition = model.PolicyCondition{"Bool": {}} }},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := valid()
+			tc.modify(&s)
+
+			err := model.BucketPolicy{Statement: []model.PolicyStatement{s}}.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrInvalidPolicy)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, model.BucketPolicy{}.Validate(), model.ErrInvalidPolicy)
+	assert.ErrorIs(t, model.BucketPolicy{Version: "2008-10-17", Statement: []model.PolicyStatement{valid()}}.Validate(),
+		model.ErrInvalidPolicy)
+}
+
+func testBucketPolicyBuilder(t *testing.T) {
+	policy, err := model.NewBucketPolicyBuilder().
+		ID("policy1").
+		Allow("read").
+		Principals("user1", "user2").
+		Actions("s3:GetObject", "s3:ListBucket").
+		Resources(model.BucketARN("bucket1"), model.ObjectsARN("bucket1")).
+		Condition("StringLike", "s3:prefix", "home/", "shared/").
+		Deny("delete").
+		AnyPrincipal().
+		Actions("s3:DeleteObject").
+		Resources(model.ObjectsARN("bucket1")).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, model.PolicyVersion, policy.Version)
+	assert.Equal(t, "policy1", policy.ID)
+	require.Len(t, policy.Statement, 2)
+	assert.Equal(t, "read", policy.Statement[0].Sid)
+	assert.Equal(t, model.StringList{"user1", "user2"}, policy.Statement[0].Principal.AWS)
+	assert.Equal(t, model.StringList{"home/", "shared/"}, policy.Statement[0].Condition["StringLike"]["s3:prefix"])
+	assert.Equal(t, model.EffectDeny, policy.Statement[1].Effect)
+	assert.True(t, policy.Statement[1].Principal.Any)
+}
+
+func testBucketPolicyBuilderInvalid(t *testing.T) {
+	_, err := model.NewBucketPolicyBuilder().Actions("s3:GetObject").Build()
+	require.ErrorIs(t, err, model.ErrInvalidPolicy)
+
+	_, err = model.NewBucketPolicyBuilder().Allow("").Principals("user1").Actions("s3:Unknown").
+		Resources(model.BucketARN("bucket1")).Build()
+	require.ErrorIs(t, err, model.ErrInvalidPolicy)
+	assert.Contains(t, err.Error(), `unsupported action "s3:Unknown"`)
+}
@@ -0,0 +1,278 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidRetention is returned when retention settings are inconsistent.
+	ErrInvalidRetention = errors.New("invalid retention")
+
+	// ErrComplianceViolation is returned when retention settings of a bucket
+	// owned by a compliance-enabled tenant would be loosened.
+	ErrComplianceViolation = errors.New("compliance violation")
+)
+
+// BucketRetentionUpdate is the request updating the default retention period
+// of a bucket.
+type BucketRetentionUpdate struct {
+	// XMLName is the name of the xml tag used XML marshalling
+	XMLName xml.Name `xml:"default_bucket_retention_update"`
+
+	// Period is the default retention period in seconds
+	Period int64 `json:"period" xml:"period"`
+
+	// Namespace is the namespace of the bucket
+	Namespace string `json:"namespace" xml:"namespace"`
+}
+
+// MinMaxGovernorUpdate is the request updating the minimum and maximum
+// retention of a bucket.
+type MinMaxGovernorUpdate struct {
+	// XMLName is the name of the xml tag used XML marshalling
+	XMLName xml.Name `xml:"min_max_governor"`
+
+	// Namespace is the namespace of the bucket
+	Namespace string `json:"namespace" xml:"namespace"`
+
+	// EnforceRetention indicates if retention should be enforced
+	EnforceRetention bool `json:"enforce_retention" xml:"enforce_retention"`
+
+	// MinimumFixedRetention is the minimum fixed retention for objects
+	MinimumFixedRetention int64 `json:"minimum_fixed_retention" xml:"minimum_fixed_retention"`
+
+	// MinimumVariableRetention is the minimum variable retention for objects
+	MinimumVariableRetention int64 `json:"minimum_variable_retention" xml:"minimum_variable_retention"`
+
+	// MaximumFixedRetention is the maximum fixed retention for objects
+	MaximumFixedRetention int64 `json:"maximum_fixed_retention" xml:"maximum_fixed_retention"`
+
+	// MaximumVariableRetention is the maximum variable retention for objects
+	MaximumVariableRetention int64 `json:"maximum_variable_retention" xml:"maximum_variable_retention"`
+}
+
+// NewMinMaxGovernorUpdate returns the request setting the retention limits of
+// the governor. The link and the state of the governor are not sent.
+func NewMinMaxGovernorUpdate(namespace string, g MinMaxGovenor) MinMaxGovernorUpdate {
+	return MinMaxGovernorUpdate{
+		Namespace:                namespace,
+		EnforceRetention:         g.EnforceRetention,
+		MinimumFixedRetention:    g.MinimumFixedRetention,
+		MinimumVariableRetention: g.MinimumVariableRetention,
+		MaximumFixedRetention:    g.MaximumFixedRetention,
+		MaximumVariableRetention: g.MaximumVariableRetention,
+	}
+}
+
+// BucketRetention are the retention settings of a bucket: the default
+// retention period and the minimum and maximum retention enforced on objects.
+// All the periods are in seconds.
+type BucketRetention struct {
+	// DefaultRetention is the default retention period for objects in bucket
+	DefaultRetention int64
+
+	// Governor enforces minimum and maximum retention for bucket objects
+	Governor MinMaxGovenor
+}
+
+// Validate checks that the governor bounds are consistent, and that the
+// default retention falls inside the fixed retention bounds of an enforcing
+// governor. A zero maximum means no maximum.
+func (r BucketRetention) Validate() error {
+	var errs []error
+
+	g := r.Governor
+
+	for _, period := range []struct {
+		name string
+		v    int64
+	}{
+		{"default retention", r.DefaultRetention},
+		{"minimum fixed retention", g.MinimumFixedRetention},
+		{"maximum fixed retention", g.MaximumFixedRetention},
+		{"minimum variable retention", g.MinimumVariableRetention},
+		{"maximum variable retention", g.MaximumVariableRetention},
+	} {
+		if period.v < 0 {
+			errs = append(errs, fmt.Errorf("negative %s %d", period.name, period.v))
+		}
+	}
+
+	if g.MaximumFixedRetention > 0 && g.MinimumFixedRetention > g.MaximumFixedRetention {
+		errs = append(errs, fmt.Errorf("minimum fixed retention %d above maximum %d",
+			g.MinimumFixedRetention, g.MaximumFixedRetention))
+	}
+
+	if g.MaximumVariableRetention > 0 && g.MinimumVariableRetention > g.MaximumVariableRetention {
+		errs = append(errs, fmt.Errorf("minimum variable retention %d above maximum %d",
+			g.MinimumVariableRetention, g.MaximumVariableRetention))
+	}
+
+	if g.EnforceRetention && r.DefaultRetention > 0 {
+		if r.DefaultRetention < g.MinimumFixedRetention {
+			errs = append(errs, fmt.Errorf("default retention %d below minimum fixed retention %d",
+				r.DefaultRetention, g.MinimumFixedRetention))
+		}
+
+		if g.MaximumFixedRetention > 0 && r.DefaultRetention > g.MaximumFixedRetention {
+			errs = append(errs, fmt.Errorf("default retention %d above maximum fixed retention %d",
+				r.DefaultRetention, g.MaximumFixedRetention))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidRetention, errors.Join(errs...))
+}
+
+// CheckNotLoosened checks that the retention settings are not looser than the
+// current ones: the default retention and the minimum retentions may not be
+// shortened, and enforcement may not be disabled. It is required for buckets
+// of compliance-enabled tenants.
+func (r BucketRetention) CheckNotLoosened(current BucketRetention) error {
+	var errs []error
+
+	if r.DefaultRetention < current.DefaultRetention {
+		errs = append(errs, fmt.Errorf("default retention shortened from %d to %d",
+			current.DefaultRetention, r.DefaultRetention))
+	}
+
+	if current.Governor.EnforceRetention && !r.Governor.EnforceRetention {
+		errs = append(errs, errors.New("retention enforcement disabled"))
+	}
+
+	if r.Governor.MinimumFixedRetention < current.Governor.MinimumFixedRetention {
+		errs = append(errs, fmt.Errorf("minimum fixed retention shortened from %d to %d",
+			current.Governor.MinimumFixedRetention, r.Governor.MinimumFixedRetention))
+	}
+
+	if r.Governor.MinimumVariableRetention < current.Governor.MinimumVariableRetention {
+		errs = append(errs, fmt.Errorf("minimum variable retention shortened from %d to %d",
+			current.Governor.MinimumVariableRetention, r.Governor.MinimumVariableRetention))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrComplianceViolation, errors.Join(errs...))
+}
+
+// CheckRetentionUpdate validates the desired retention settings and, if the
+// tenant owning the bucket is compliance-enabled, checks that they do not
+// loosen the current settings.
+func CheckRetentionUpdate(tenant Tenant, current, desired BucketRetention) error {
+	if err := desired.Validate(); err != nil {
+		return err
+	}
+
+	if tenant.ComplianceEnabled {
+		return desired.CheckNotLoosened(current)
+	}
+
+	return nil
+}
+
+// Equal reports whether both retention settings have the same default
+// retention and governor limits, ignoring the link and the state of the
+// governors.
+func (r BucketRetention) Equal(o BucketRetention) bool {
+	return r.DefaultRetention == o.DefaultRetention && limits(r.Governor) == limits(o.Governor)
+}
+
+// RetentionStep is a single write of a retention update.
+type RetentionStep struct {
+	// Governor is true if the step writes the governor of Retention, and false
+	// if it writes its default retention
+	Governor bool
+
+	// Retention are the settings after the step
+	Retention BucketRetention
+}
+
+// Steps returns the writes updating the retention settings from current to
+// r. The management API refuses a default retention outside of an enforcing
+// governor, so the default retention is written first if the current governor
+// admits it, and the governor first otherwise. If neither order works, the
+// governor is first widened to admit both default retentions.
+func (r BucketRetention) Steps(current BucketRetention) []RetentionStep {
+	governor := func(g MinMaxGovenor, defaultRetention int64) RetentionStep {
+		return RetentionStep{Governor: true, Retention: BucketRetention{DefaultRetention: defaultRetention, Governor: g}}
+	}
+
+	governorChanged := limits(r.Governor) != limits(current.Governor)
+	defaultChanged := r.DefaultRetention != current.DefaultRetention
+
+	switch {
+	case !governorChanged && !defaultChanged:
+		return nil
+	case !governorChanged:
+		return []RetentionStep{{Retention: r}}
+	case !defaultChanged:
+		return []RetentionStep{governor(r.Governor, r.DefaultRetention)}
+	case admits(current.Governor, r.DefaultRetention):
+		return []RetentionStep{
+			{Retention: BucketRetention{DefaultRetention: r.DefaultRetention, Governor: current.Governor}},
+			governor(r.Governor, r.DefaultRetention),
+		}
+	case admits(r.Governor, current.DefaultRetention):
+		return []RetentionStep{
+			governor(r.Governor, current.DefaultRetention),
+			{Retention: r},
+		}
+	}
+
+	widened := r.Governor
+	widened.MinimumFixedRetention = min(r.Governor.MinimumFixedRetention, current.Governor.MinimumFixedRetention)
+
+	if r.Governor.MaximumFixedRetention == 0 || current.Governor.MaximumFixedRetention == 0 {
+		widened.MaximumFixedRetention = 0
+	} else {
+		widened.MaximumFixedRetention = max(r.Governor.MaximumFixedRetention, current.Governor.MaximumFixedRetention)
+	}
+
+	return []RetentionStep{
+		governor(widened, current.DefaultRetention),
+		{Retention: BucketRetention{DefaultRetention: r.DefaultRetention, Governor: widened}},
+		governor(r.Governor, r.DefaultRetention),
+	}
+}
+
+// admits reports whether the governor accepts the default retention. A zero
+// default retention means no default retention.
+func admits(g MinMaxGovenor, defaultRetention int64) bool {
+	if !g.EnforceRetention || defaultRetention == 0 {
+		return true
+	}
+
+	return defaultRetention >= g.MinimumFixedRetention &&
+		(g.MaximumFixedRetention == 0 || defaultRetention <= g.MaximumFixedRetention)
+}
+
+// limits returns the retention limits of the governor, without its link and
+// state.
+func limits(g MinMaxGovenor) MinMaxGovenor {
+	return MinMaxGovenor{
+		EnforceRetention:         g.EnforceRetention,
+		MinimumFixedRetention:    g.MinimumFixedRetention,
+		MinimumVariableRetention: g.MinimumVariableRetention,
+		MaximumFixedRetention:    g.MaximumFixedRetention,
+		MaximumVariableRetention: g.MaximumVariableRetention,
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetention(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Validate":         testRetentionValidate,
+		"CheckNotLoosened": testRetentionCheckNotLoosened,
+		"CheckUpdate":      testRetentionCheckUpdate,
+		"Equal":            testRetentionEqual,
+		"Steps":            testRetentionSteps,
+		"GovernorUpdate":   testRetentionGovernorUpdate,
+		"BucketGovernor":   testRetentionBucketGovernor,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testRetentionValidate(t *testing.T) {
+	governor := model.MinMaxGovenor{EnforceRetention: true, MinimumFixedRetention: 10, MaximumFixedRetention: 100}
+
+	for name, tc := range map[string]struct {
+		retention model.BucketRetention
+		valid     bool
+	}{
+		"inside bounds":     {retention: model.BucketRetention{DefaultRetention: 50, Governor: governor}, valid: true},
+		"no default":        {retention: model.BucketRetention{Governor: governor}, valid: true},
+		"no maximum":        {retention: model.BucketRetention{DefaultRetention: 500, Governor: model.MinMaxGovenor{EnforceRetention: true}}, valid: true},
+		"not enforced":      {retention: model.BucketRetention{DefaultRetention: 5, Governor: model.MinMaxGovenor{MinimumFixedRetention: 10}}, valid: true},
+		"below minimum":     {retention: model.BucketRetention{DefaultRetention: 5, Governor: governor}},
+		"above maximum":     {retention: model.BucketRetention{DefaultRetention: 500, Governor: governor}},
+		"negative":          {retention: model.BucketRetention{DefaultRetention: -1}},
+		"inverted fixed":    {retention: model.BucketRetention{Governor: model.MinMaxGovenor{MinimumFixedRetention: 10, MaximumFixedRetention: 5}}},
+		"inverted variable": {retention: model.BucketRetention{Governor: model.MinMaxGovenor{MinimumVariableRetention: 10, MaximumVariableRetention: 5}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.retention.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrInvalidRetention)
+			}
+		})
+	}
+}
+
+func testRetentionCheckNotLoosened(t *testing.T) {
+	current := model.BucketRetention{
+		DefaultRetention: 50,
+		Governor:         model.MinMaxGovenor{EnforceRetention: true, MinimumFixedRetention: 10, MinimumVariableRetention: 10},
+	}
+
+	tighter := current
+	tighter.DefaultRetention = 60
+	tighter.Governor.MinimumFixedRetention = 20
+	assert.NoError(t, tighter.CheckNotLoosened(current))
+
+	for name, modify := range map[string]func(r *model.BucketRetention){
+		"default shortened":          func(r *model.BucketRetention) { r.DefaultRetention = 40 },
+		"enforcement disabled":       func(r *model.BucketRetention) { r.Governor.EnforceRetention = false },
+		"minimum fixed shortened":    func(r *model.BucketRetention) { r.Governor.MinimumFixedRetention = 5 },
+		"minimum variable shortened": func(r *model.BucketRetention) { r.Governor.MinimumVariableRetention = 5 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			looser := current
+			modify(&looser)
+			assert.ErrorIs(t, looser.CheckNotLoosened(current), model.ErrComplianceViolation)
+		})
+	}
+}
+
+func testRetentionCheckUpdate(t *testing.T) {
+	current := model.BucketRetention{DefaultRetention: 50}
+	desired := model.BucketRetention{DefaultRetention: 10}
+
+	assert.NoError(t, model.CheckRetentionUpdate(model.Tenant{}, current, desired))
+	assert.ErrorIs(t, model.CheckRetentionUpdate(model.Tenant{ComplianceEnabled: true}, current, desired),
+		model.ErrComplianceViolation)
+	assert.ErrorIs(t, model.CheckRetentionUpdate(model.Tenant{}, current, model.BucketRetention{DefaultRetention: -1}),
+		model.ErrInvalidRetention)
+}
+
+func testRetentionEqual(t *testing.T) {
+	a := model.BucketRetention{Governor: model.MinMaxGovenor{EnforceRetention: true, Link: model.Link{HREF: "/object/bucket/a"}}}
+	b := model.BucketRetention{Governor: model.MinMaxGovenor{EnforceRetention: true, Inactive: true}}
+	assert.True(t, a.Equal(b))
+
+	b.Governor.MaximumFixedRetention = 10
+	assert.False(t, a.Equal(b))
+
+	b = a
+	b.DefaultRetention = 10
+	assert.False(t, a.Equal(b))
+}
+
+func testRetentionSteps(t *testing.T) {
+	governor := func(minimum, maximum int64) model.MinMaxGovenor {
+		return model.MinMaxGovenor{EnforceRetention: true, MinimumFixedRetention: minimum, MaximumFixedRetention: maximum}
+	}
+
+	// kinds returns the kinds of the steps, and checks that the governor admits
+	// the default retention after every step.
+	kinds := func(current, desired model.BucketRetention) []string {
+		var kinds []string
+
+		for _, step := range desired.Steps(current) {
+			require.NoError(t, step.Retention.Validate())
+
+			if step.Governor {
+				kinds = append(kinds, "governor")
+			} else {
+				kinds = append(kinds, "default")
+			}
+		}
+
+		return kinds
+	}
+
+	current := model.BucketRetention{DefaultRetention: 15, Governor: governor(10, 20)}
+
+	assert.Empty(t, kinds(current, current))
+	assert.Equal(t, []string{"default"}, kinds(current, model.BucketRetention{DefaultRetention: 18, Governor: governor(10, 20)}))
+	assert.Equal(t, []string{"governor"}, kinds(current, model.BucketRetention{DefaultRetention: 15, Governor: governor(15, 20)}))
+
+	// Tightening the governor: the default retention is moved first.
+	assert.Equal(t, []string{"default", "governor"},
+		kinds(current, model.BucketRetention{DefaultRetention: 19, Governor: governor(18, 20)}))
+
+	// Loosening the governor: the governor is moved first.
+	assert.Equal(t, []string{"governor", "default"},
+		kinds(current, model.BucketRetention{DefaultRetention: 30, Governor: governor(10, 40)}))
+
+	// Disjoint governors: the governor is widened first.
+	desired := model.BucketRetention{DefaultRetention: 35, Governor: governor(30, 40)}
+	assert.Equal(t, []string{"governor", "default", "governor"}, kinds(current, desired))
+
+	steps := desired.Steps(current)
+	assert.Equal(t, governor(10, 40), steps[0].Retention.Governor)
+	assert.Equal(t, desired, steps[2].Retention)
+}
+
+func testRetentionGovernorUpdate(t *testing.T) {
+	g := model.MinMaxGovenor{EnforceRetention: true, MinimumFixedRetention: 10, Link: model.Link{HREF: "/governor"}, Inactive: true}
+
+	out, err := xml.Marshal(model.NewMinMaxGovernorUpdate("ns1", g))
+	require.NoError(t, err)
+	assert.Equal(t, `<min_max_governor><namespace>ns1</namespace><enforce_retention>true</enforce_retention>`+
+		`<minimum_fixed_retention>10</minimum_fixed_retention><minimum_variable_retention>0</minimum_variable_retention>`+
+		`<maximum_fixed_retention>0</maximum_fixed_retention><maximum_variable_retention>0</maximum_variable_retention>`+
+		`</min_max_governor>`, string(out))
+}
+
+func testRetentionBucketGovernor(t *testing.T) {
+	var bucket model.Bucket
+
+	require.NoError(t, xml.Unmarshal([]byte(`<object_bucket><name>bucket1</name><default_retention>100</default_retention>`+
+		`<min_max_governor><enforce_retention>true</enforce_retention><minimum_fixed_retention>50</minimum_fixed_retention>`+
+		`</min_max_governor></object_bucket>`), &bucket))
+	assert.Equal(t, int64(100), bucket.DefaultRetention)
+	assert.True(t, bucket.EnforceRetention)
+	assert.Equal(t, int64(50), bucket.MinimumFixedRetention)
+}
@@ -12,6 +12,59 @@
 
 package model
 
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxSearchMetadataKeys is the maximum number of metadata search keys a bucket
+// can be created with.
+const MaxSearchMetadataKeys = 30
+
+// UserMetadataPrefix is the prefix of user metadata key names.
+const UserMetadataPrefix = "x-amz-meta-"
+
+// ErrInvalidSearchMetadata is returned when a search metadata configuration
+// does not pass validation.
+var ErrInvalidSearchMetadata = errors.New("invalid search metadata")
+
+// MetadataType is the type of a metadata search key.
+type MetadataType string
+
+// Metadata search key types.
+const (
+	// MetadataTypeUser is a user defined metadata key.
+	MetadataTypeUser MetadataType = "User"
+	// MetadataTypeSystem is a system metadata key.
+	MetadataTypeSystem MetadataType = "System"
+)
+
+// MetadataDatatype is the data type of a metadata search key value.
+type MetadataDatatype string
+
+// Metadata search key data types.
+const (
+	DatatypeString   MetadataDatatype = "string"
+	DatatypeInteger  MetadataDatatype = "integer"
+	DatatypeDecimal  MetadataDatatype = "decimal"
+	DatatypeDateTime MetadataDatatype = "datetime"
+)
+
+// MetadataDatatypes are the data types supported for metadata search keys.
+var MetadataDatatypes = []MetadataDatatype{DatatypeString, DatatypeInteger, DatatypeDecimal, DatatypeDateTime}
+
+// SystemMetadataKeys are the system metadata keys that can be searched upon,
+// with the data type of their values.
+var SystemMetadataKeys = map[string]MetadataDatatype{
+	"ObjectName":   DatatypeString,
+	"Owner":        DatatypeString,
+	"Size":         DatatypeInteger,
+	"CreateTime":   DatatypeDateTime,
+	"LastModified": DatatypeDateTime,
+}
+
 // SearchMetadata is the custom metadata for enabled for querying on the
 // bucket.
 type SearchMetadata struct {
@@ -23,17 +76,116 @@ type SearchMetadata struct {
 	Enabled bool `json:"isEnabled" xml:"isEnabled"`
 
 	// Metadata defines the fields that can be searched upon
-	Metadata `json:"metadata" xml:"metadata"`
+	Metadata []Metadata `json:"metadata,omitempty" xml:"metadata,omitempty"`
 }
 
 // Metadata defines the fields that can be searched upon.
 type Metadata struct {
 	// Type is the metadata key type
-	Type string `json:"type" xml:"type"`
+	Type MetadataType `json:"type" xml:"type"`
 
 	// Name is the metadata key name
 	Name string `json:"name" xml:"name"`
 
 	// Datatype is the data type of the metadata value
-	Datatype string `json:"datatype" xml:"datatype"`
+	Datatype MetadataDatatype `json:"datatype" xml:"datatype"`
+}
+
+// UserMetadata returns a user metadata search key. The user metadata prefix is
+// added to the name if it is missing.
+func UserMetadata(name string, datatype MetadataDatatype) Metadata {
+	if !strings.HasPrefix(strings.ToLower(name), UserMetadataPrefix) {
+		name = UserMetadataPrefix + name
+	}
+
+	return Metadata{Type: MetadataTypeUser, Name: name, Datatype: datatype}
+}
+
+// SystemMetadata returns a system metadata search key with the data type of
+// the system key, or an empty data type if the key is unknown.
+func SystemMetadata(name string) Metadata {
+	return Metadata{Type: MetadataTypeSystem, Name: name, Datatype: SystemMetadataKeys[name]}
+}
+
+// NewSearchMetadata returns a search metadata configuration enabled for the
+// keys, to be set on a bucket at creation.
+func NewSearchMetadata(keys ...Metadata) (SearchMetadata, error) {
+	metadata := SearchMetadata{
+		MaxKeys:  len(keys),
+		Enabled:  true,
+		Metadata: keys,
+	}
+
+	return metadata, metadata.ValidateSearchMetadata()
+}
+
+// ValidateSearchMetadata checks the search metadata configuration. Disabled
+// configurations must not define keys; enabled ones must define between one
+// and MaxKeys distinct keys of known types and data types. All problems found
+// are reported, and the returned error matches ErrInvalidSearchMetadata.
+func (s SearchMetadata) ValidateSearchMetadata() error {
+	var errs []error
+
+	if s.MaxKeys < 0 || s.MaxKeys > MaxSearchMetadataKeys {
+		errs = append(errs, fmt.Errorf("maxKeys must be between 0 and %d, got %d", MaxSearchMetadataKeys, s.MaxKeys))
+	}
+
+	switch {
+	case !s.Enabled && len(s.Metadata) > 0:
+		errs = append(errs, errors.New("metadata keys defined while search metadata is disabled"))
+	case s.Enabled && len(s.Metadata) == 0:
+		errs = append(errs, errors.New("at least one metadata key is required"))
+	case s.MaxKeys > 0 && len(s.Metadata) > s.MaxKeys:
+		errs = append(errs, fmt.Errorf("%d metadata keys exceed maxKeys %d", len(s.Metadata), s.MaxKeys))
+	case len(s.Metadata) > MaxSearchMetadataKeys:
+		errs = append(errs, fmt.Errorf("%d metadata keys exceed the limit of %d", len(s.Metadata), MaxSearchMetadataKeys))
+	}
+
+	seen := make(map[string]bool, len(s.Metadata))
+
+	for i, key := range s.Metadata {
+		if err := key.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("metadata[%d]: %w", i, err))
+		}
+
+		name := strings.ToLower(key.Name)
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("metadata[%d]: duplicate key %q", i, key.Name))
+		}
+
+		seen[name] = true
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidSearchMetadata, errors.Join(errs...))
+}
+
+// Validate checks the metadata search key.
+func (m Metadata) Validate() error {
+	if !slices.Contains(MetadataDatatypes, m.Datatype) {
+		return fmt.Errorf("unknown datatype %q for key %q", m.Datatype, m.Name)
+	}
+
+	switch m.Type {
+	case MetadataTypeUser:
+		if !strings.HasPrefix(strings.ToLower(m.Name), UserMetadataPrefix) || len(m.Name) == len(UserMetadataPrefix) {
+			return fmt.Errorf("user key %q must be named %s<name>", m.Name, UserMetadataPrefix)
+		}
+	case MetadataTypeSystem:
+		datatype, ok := SystemMetadataKeys[m.Name]
+		if !ok {
+			return fmt.Errorf("unknown system key %q", m.Name)
+		}
+
+		if datatype != m.Datatype {
+			return fmt.Errorf("system key %q must have datatype %q, got %q", m.Name, datatype, m.Datatype)
+		}
+	default:
+		return fmt.Errorf("unknown type %q for key %q", m.Type, m.Name)
+	}
+
+	return nil
 }
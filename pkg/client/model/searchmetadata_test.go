@@ -0,0 +1,134 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleBucket is a hand-written bucket with search metadata enabled, in the
+// layout of the bucket info responses. No recorded response of a bucket with
+// search metadata enabled is available.
+const sampleBucket = `<object_bucket><name>searchbucket1</name><namespace>search-ns1</namespace>` +
+	`<search_metadata><maxKeys>3</maxKeys><isEnabled>true</isEnabled>` +
+	`<metadata><type>System</type><name>Size</name><datatype>integer</datatype></metadata>` +
+	`<metadata><type>User</type><name>x-amz-meta-region</name><datatype>string</datatype></metadata>` +
+	`<metadata><type>User</type><name>x-amz-meta-captured</name><datatype>datetime</datatype></metadata>` +
+	`</search_metadata></object_bucket>`
+
+func TestSearchMetadata(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Validate":          testSearchMetadataValidate,
+		"NewSearchMetadata": testNewSearchMetadata,
+		"XMLRoundTrip":      testSearchMetadataXMLRoundTrip,
+		"JSON":              testSearchMetadataJSON,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testSearchMetadataValidate(t *testing.T) {
+	size := model.SystemMetadata("Size")
+	region := model.UserMetadata("region", model.DatatypeString)
+
+	tooMany := make([]model.Metadata, 0, model.MaxSearchMetadataKeys+1)
+	for i := 0; i <= model.MaxSearchMetadataKeys; i++ {
+		tooMany = append(tooMany, model.UserMetadata(fmt.Sprint("key", i), model.DatatypeInteger))
+	}
+
+	for name, tc := range map[string]struct {
+		metadata model.SearchMetadata
+		valid    bool
+	}{
+		"disabled":           {metadata: model.SearchMetadata{}, valid: true},
+		"enabled":            {metadata: model.SearchMetadata{MaxKeys: 2, Enabled: true, Metadata: []model.Metadata{size, region}}, valid: true},
+		"no max keys":        {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{size}}, valid: true},
+		"keys when disabled": {metadata: model.SearchMetadata{Metadata: []model.Metadata{size}}},
+		"no keys":            {metadata: model.SearchMetadata{Enabled: true}},
+		"above max keys":     {metadata: model.SearchMetadata{MaxKeys: 1, Enabled: true, Metadata: []model.Metadata{size, region}}},
+		"above limit":        {metadata: model.SearchMetadata{Enabled: true, Metadata: tooMany}},
+		"max keys too large": {metadata: model.SearchMetadata{MaxKeys: model.MaxSearchMetadataKeys + 1, Enabled: true, Metadata: []model.Metadata{size}}},
+		"duplicate key":      {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{region, model.UserMetadata("X-Amz-Meta-Region", model.DatatypeString)}}},
+		"unknown datatype":   {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{model.UserMetadata("region", "text")}}},
+		"unknown type":       {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{{Type: "Custom", Name: "region", Datatype: model.DatatypeString}}}},
+		"unprefixed user":    {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{{Type: model.MetadataTypeUser, Name: "region", Datatype: model.DatatypeString}}}},
+		"unknown system":     {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{model.SystemMetadata("Color")}}},
+		"system datatype":    {metadata: model.SearchMetadata{Enabled: true, Metadata: []model.Metadata{{Type: model.MetadataTypeSystem, Name: "Size", Datatype: model.DatatypeString}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.metadata.ValidateSearchMetadata()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, model.ErrInvalidSearchMetadata)
+			}
+		})
+	}
+}
+
+func testNewSearchMetadata(t *testing.T) {
+	metadata, err := model.NewSearchMetadata(model.SystemMetadata("LastModified"), model.UserMetadata("x-amz-meta-price", model.DatatypeDecimal))
+	require.NoError(t, err)
+	assert.True(t, metadata.Enabled)
+	assert.Equal(t, 2, metadata.MaxKeys)
+	assert.Equal(t, "x-amz-meta-price", metadata.Metadata[1].Name)
+
+	_, err = model.NewSearchMetadata()
+	assert.ErrorIs(t, err, model.ErrInvalidSearchMetadata)
+}
+
+func testSearchMetadataXMLRoundTrip(t *testing.T) {
+	bucket := model.Bucket{}
+	require.NoError(t, xml.Unmarshal([]byte(sampleBucket), &bucket))
+
+	assert.Equal(t, "searchbucket1", bucket.Name)
+	assert.Equal(t, model.SearchMetadata{
+		MaxKeys: 3,
+		Enabled: true,
+		Metadata: []model.Metadata{
+			{Type: model.MetadataTypeSystem, Name: "Size", Datatype: model.DatatypeInteger},
+			{Type: model.MetadataTypeUser, Name: "x-amz-meta-region", Datatype: model.DatatypeString},
+			{Type: model.MetadataTypeUser, Name: "x-amz-meta-captured", Datatype: model.DatatypeDateTime},
+		},
+	}, bucket.SearchMetadata)
+
+	assert.Equal(t, 3, bucket.MaxKeys)
+	assert.True(t, bucket.Enabled)
+
+	out, err := xml.Marshal(model.BucketCreate{Bucket: bucket})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `<object_bucket_create><name>searchbucket1</name>`)
+	assert.Contains(t, string(out), `<search_metadata><maxKeys>3</maxKeys><isEnabled>true</isEnabled><metadata>`)
+	assert.NotContains(t, string(out), `<isEnabled>true</isEnabled><search_metadata>`)
+
+	again := model.Bucket{}
+	require.NoError(t, xml.Unmarshal(out, &again))
+	assert.Equal(t, bucket.SearchMetadata, again.SearchMetadata)
+	assert.Equal(t, bucket.Name, again.Name)
+}
+
+func testSearchMetadataJSON(t *testing.T) {
+	metadata, err := model.NewSearchMetadata(model.SystemMetadata("Owner"))
+	require.NoError(t, err)
+
+	out, err := json.Marshal(metadata)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"maxKeys":1,"isEnabled":true,"metadata":[{"type":"System","name":"Owner","datatype":"string"}]}`, string(out))
+}
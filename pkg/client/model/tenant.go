@@ -89,6 +89,13 @@ type TenantList struct {
 
 	// Items is the list of tenants in the list
 	Items []Tenant `json:"tenant" xml:"tenant"`
+
+	// NextMarker is a reference object to receive the next set of tenants
+	NextMarker string `json:"next_marker,omitempty" xml:"NextMarker,omitempty"`
+
+	// Filter is a string query used to limit the returned tenants in the
+	// listing
+	Filter string `json:"Filter,omitempty" xml:"Filter,omitempty"`
 }
 
 // TenantQuota is an object store tenant quota.
@@ -0,0 +1,270 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyeval
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// ErrUnsupportedOperator is returned for condition operators the evaluator
+// does not know.
+var ErrUnsupportedOperator = errors.New("unsupported condition operator")
+
+// ErrInvalidConditionValue is returned for condition values that cannot be
+// compared with the operator, e.g. a non-numeric value of NumericEquals.
+var ErrInvalidConditionValue = errors.New("invalid condition value")
+
+// compareFunc reports whether a request value matches a policy value.
+type compareFunc func(value, policyValue string) (bool, error)
+
+// operator is a condition operator.
+type operator struct {
+	compare compareFunc
+	negated bool
+}
+
+// operators are the condition operators, without the IfExists suffix and the
+// set operator prefixes.
+var operators = map[string]operator{
+	"StringEquals":              {compare: stringEquals},
+	"StringNotEquals":           {compare: stringEquals, negated: true},
+	"StringEqualsIgnoreCase":    {compare: stringEqualsIgnoreCase},
+	"StringNotEqualsIgnoreCase": {compare: stringEqualsIgnoreCase, negated: true},
+	"StringLike":                {compare: stringLike},
+	"StringNotLike":             {compare: stringLike, negated: true},
+	"NumericEquals":             {compare: numeric(func(c int) bool { return c == 0 })},
+	"NumericNotEquals":          {compare: numeric(func(c int) bool { return c == 0 }), negated: true},
+	"NumericLessThan":           {compare: numeric(func(c int) bool { return c < 0 })},
+	"NumericLessThanEquals":     {compare: numeric(func(c int) bool { return c <= 0 })},
+	"NumericGreaterThan":        {compare: numeric(func(c int) bool { return c > 0 })},
+	"NumericGreaterThanEquals":  {compare: numeric(func(c int) bool { return c >= 0 })},
+	"DateEquals":                {compare: date(func(c int) bool { return c == 0 })},
+	"DateNotEquals":             {compare: date(func(c int) bool { return c == 0 }), negated: true},
+	"DateLessThan":              {compare: date(func(c int) bool { return c < 0 })},
+	"DateLessThanEquals":        {compare: date(func(c int) bool { return c <= 0 })},
+	"DateGreaterThan":           {compare: date(func(c int) bool { return c > 0 })},
+	"DateGreaterThanEquals":     {compare: date(func(c int) bool { return c >= 0 })},
+	"Bool":                      {compare: stringEqualsIgnoreCase},
+	"IpAddress":                 {compare: ipAddress},
+	"NotIpAddress":              {compare: ipAddress, negated: true},
+	"ArnEquals":                 {compare: stringLike},
+	"ArnNotEquals":              {compare: stringLike, negated: true},
+	"ArnLike":                   {compare: stringLike},
+	"ArnNotLike":                {compare: stringLike, negated: true},
+}
+
+// normalizeContext returns the context with lower-case keys.
+func normalizeContext(context map[string][]string) map[string][]string {
+	normalized := make(map[string][]string, len(context))
+
+	for key, values := range context {
+		normalized[strings.ToLower(key)] = append(normalized[strings.ToLower(key)], values...)
+	}
+
+	return normalized
+}
+
+// matchConditions reports whether all the conditions are met by the request
+// context.
+func matchConditions(conditions model.PolicyCondition, context map[string][]string) (bool, error) {
+	for op, keys := range conditions {
+		for key, policyValues := range keys {
+			values, present := context[strings.ToLower(key)]
+
+			ok, err := matchCondition(op, policyValues, values, present)
+			if err != nil {
+				return false, fmt.Errorf("condition %s %s: %w", op, key, err)
+			}
+
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// matchCondition reports whether the request values of a condition key match
+// the policy values with the operator.
+func matchCondition(op string, policyValues model.StringList, values []string, present bool) (bool, error) {
+	if op == "Null" {
+		if len(policyValues) != 1 {
+			return false, fmt.Errorf("%w: Null takes a single value", ErrInvalidConditionValue)
+		}
+
+		absent, err := strconv.ParseBool(policyValues[0])
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidConditionValue, err)
+		}
+
+		return absent != present, nil
+	}
+
+	forAll := strings.HasPrefix(op, "ForAllValues:")
+	forAny := strings.HasPrefix(op, "ForAnyValue:")
+	name := strings.TrimPrefix(strings.TrimPrefix(op, "ForAllValues:"), "ForAnyValue:")
+	ifExists := strings.HasSuffix(name, "IfExists")
+	name = strings.TrimSuffix(name, "IfExists")
+
+	operator, ok := operators[name]
+	if !ok {
+		return false, fmt.Errorf("%w: %q", ErrUnsupportedOperator, op)
+	}
+
+	if !present || len(values) == 0 {
+		switch {
+		case ifExists, forAll:
+			return true, nil
+		case forAny:
+			return false, nil
+		default:
+			// Negated operators match keys missing from the request.
+			return operator.negated, nil
+		}
+	}
+
+	// matches reports whether the request value matches one of the policy
+	// values, with the negation of the operator applied.
+	matches := func(value string) (bool, error) {
+		for _, policyValue := range policyValues {
+			ok, err := operator.compare(value, policyValue)
+			if err != nil {
+				return false, err
+			}
+
+			if ok {
+				return !operator.negated, nil
+			}
+		}
+
+		return operator.negated, nil
+	}
+
+	// ForAllValues, and negated operators unless ForAnyValue, require every
+	// request value to match; others require a single one.
+	if forAll || (operator.negated && !forAny) {
+		for _, value := range values {
+			if ok, err := matches(value); err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	for _, value := range values {
+		if ok, err := matches(value); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+func stringEquals(value, policyValue string) (bool, error) {
+	return value == policyValue, nil
+}
+
+func stringEqualsIgnoreCase(value, policyValue string) (bool, error) {
+	return strings.EqualFold(value, policyValue), nil
+}
+
+func stringLike(value, policyValue string) (bool, error) {
+	return wildcardMatch(policyValue, value, false), nil
+}
+
+// numeric returns a comparison of numbers, with cmp applied to the result of
+// comparing the request value to the policy value.
+func numeric(cmp func(c int) bool) compareFunc {
+	return func(value, policyValue string) (bool, error) {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidConditionValue, err)
+		}
+
+		p, err := strconv.ParseFloat(policyValue, 64)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidConditionValue, err)
+		}
+
+		switch {
+		case v < p:
+			return cmp(-1), nil
+		case v > p:
+			return cmp(1), nil
+		default:
+			return cmp(0), nil
+		}
+	}
+}
+
+// date returns a comparison of dates, either RFC 3339 or epoch seconds, with
+// cmp applied to the result of comparing the request value to the policy value.
+func date(cmp func(c int) bool) compareFunc {
+	return func(value, policyValue string) (bool, error) {
+		v, err := parseDate(value)
+		if err != nil {
+			return false, err
+		}
+
+		p, err := parseDate(policyValue)
+		if err != nil {
+			return false, err
+		}
+
+		return cmp(v.Compare(p)), nil
+	}
+}
+
+func parseDate(s string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w: date %q", ErrInvalidConditionValue, s)
+}
+
+// ipAddress reports whether the request IP address is in the policy CIDR
+// block; a single address is accepted in place of a block.
+func ipAddress(value, policyValue string) (bool, error) {
+	prefix, err := netip.ParsePrefix(policyValue)
+	if err != nil {
+		addr, addrErr := netip.ParseAddr(policyValue)
+		if addrErr != nil {
+			return false, fmt.Errorf("%w: %w", ErrInvalidConditionValue, err)
+		}
+
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidConditionValue, err)
+	}
+
+	return prefix.Contains(addr.Unmap()), nil
+}
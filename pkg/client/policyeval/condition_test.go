@@ -0,0 +1,119 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyeval_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/policyeval"
+)
+
+// conditionPolicy returns a policy allowing everything under the condition.
+func conditionPolicy(operator, key string, values ...string) policyeval.Policy {
+	return policyeval.Policy{
+		Name: "condition",
+		Kind: policyeval.Identity,
+		Document: model.BucketPolicy{Statement: []model.PolicyStatement{{
+			Effect:    model.EffectAllow,
+			Action:    model.StringList{"*"},
+			Resource:  model.StringList{"*"},
+			Condition: model.PolicyCondition{operator: {key: values}},
+		}}},
+	}
+}
+
+func TestCondition(t *testing.T) {
+	for name, tc := range map[string]struct {
+		operator string
+		key      string
+		values   []string
+		context  map[string][]string
+		match    bool
+	}{
+		"string equals":             {"StringEquals", "s3:prefix", []string{"home/"}, map[string][]string{"s3:prefix": {"home/"}}, true},
+		"string equals other":       {"StringEquals", "s3:prefix", []string{"home/"}, map[string][]string{"s3:prefix": {"tmp/"}}, false},
+		"string equals missing":     {"StringEquals", "s3:prefix", []string{"home/"}, nil, false},
+		"key case insensitive":      {"StringEquals", "S3:Prefix", []string{"home/"}, map[string][]string{"s3:PREFIX": {"home/"}}, true},
+		"string not equals":         {"StringNotEquals", "s3:prefix", []string{"home/"}, map[string][]string{"s3:prefix": {"tmp/"}}, true},
+		"string not equals same":    {"StringNotEquals", "s3:prefix", []string{"home/"}, map[string][]string{"s3:prefix": {"home/"}}, false},
+		"string not equals missing": {"StringNotEquals", "s3:prefix", []string{"home/"}, nil, true},
+		"string not equals multi":   {"StringNotEquals", "k", []string{"a"}, map[string][]string{"k": {"b", "a"}}, false},
+		"ignore case":               {"StringEqualsIgnoreCase", "k", []string{"Home"}, map[string][]string{"k": {"hOME"}}, true},
+		"string like":               {"StringLike", "s3:prefix", []string{"home/*"}, map[string][]string{"s3:prefix": {"home/u1/"}}, true},
+		"string not like":           {"StringNotLike", "s3:prefix", []string{"home/*"}, map[string][]string{"s3:prefix": {"home/u1/"}}, false},
+		"if exists missing":         {"StringEqualsIfExists", "k", []string{"v"}, nil, true},
+		"if exists present":         {"StringEqualsIfExists", "k", []string{"v"}, map[string][]string{"k": {"w"}}, false},
+		"numeric less than":         {"NumericLessThan", "s3:max-keys", []string{"100"}, map[string][]string{"s3:max-keys": {"10"}}, true},
+		"numeric greater":           {"NumericGreaterThanEquals", "s3:max-keys", []string{"100"}, map[string][]string{"s3:max-keys": {"10"}}, false},
+		"numeric not equals":        {"NumericNotEquals", "n", []string{"1.5"}, map[string][]string{"n": {"1.50"}}, false},
+		"date less than":            {"DateLessThan", "aws:CurrentTime", []string{"2024-01-01T00:00:00Z"}, map[string][]string{"aws:CurrentTime": {"2023-06-01T12:00:00Z"}}, true},
+		"date epoch":                {"DateGreaterThan", "aws:EpochTime", []string{"2023-01-01"}, map[string][]string{"aws:EpochTime": {"1700000000"}}, true},
+		"bool":                      {"Bool", "aws:SecureTransport", []string{"true"}, map[string][]string{"aws:SecureTransport": {"TRUE"}}, true},
+		"bool false":                {"Bool", "aws:SecureTransport", []string{"true"}, map[string][]string{"aws:SecureTransport": {"false"}}, false},
+		"ip address":                {"IpAddress", "aws:SourceIp", []string{"10.0.0.0/8"}, map[string][]string{"aws:SourceIp": {"10.1.2.3"}}, true},
+		"ip address single":         {"IpAddress", "aws:SourceIp", []string{"10.0.0.1"}, map[string][]string{"aws:SourceIp": {"10.0.0.2"}}, false},
+		"not ip address":            {"NotIpAddress", "aws:SourceIp", []string{"10.0.0.0/8", "192.168.0.0/16"}, map[string][]string{"aws:SourceIp": {"172.16.0.1"}}, true},
+		"ip v6":                     {"IpAddress", "aws:SourceIp", []string{"2001:db8::/32"}, map[string][]string{"aws:SourceIp": {"2001:db8::1"}}, true},
+		"arn like":                  {"ArnLike", "aws:SourceArn", []string{"urn:ecs:iam::ns1:role/*"}, map[string][]string{"aws:SourceArn": {"urn:ecs:iam::ns1:role/app"}}, true},
+		"arn not equals":            {"ArnNotEquals", "aws:SourceArn", []string{"urn:ecs:iam::ns1:role/app"}, map[string][]string{"aws:SourceArn": {"urn:ecs:iam::ns1:role/app"}}, false},
+		"null absent":               {"Null", "s3:x-amz-server-side-encryption", []string{"true"}, nil, true},
+		"null present":              {"Null", "s3:x-amz-server-side-encryption", []string{"true"}, map[string][]string{"s3:x-amz-server-side-encryption": {"AES256"}}, false},
+		"not null present":          {"Null", "k", []string{"false"}, map[string][]string{"k": {"v"}}, true},
+		"for all values":            {"ForAllValues:StringEquals", "tags", []string{"a", "b"}, map[string][]string{"tags": {"a", "b"}}, true},
+		"for all values extra":      {"ForAllValues:StringEquals", "tags", []string{"a", "b"}, map[string][]string{"tags": {"a", "c"}}, false},
+		"for all values missing":    {"ForAllValues:StringEquals", "tags", []string{"a"}, nil, true},
+		"for any value":             {"ForAnyValue:StringEquals", "tags", []string{"a"}, map[string][]string{"tags": {"c", "a"}}, true},
+		"for any value missing":     {"ForAnyValue:StringEquals", "tags", []string{"a"}, nil, false},
+		"for any value negated":     {"ForAnyValue:StringNotEquals", "tags", []string{"a"}, map[string][]string{"tags": {"a", "c"}}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := policyeval.Evaluate(policyeval.Request{
+				Action:   "s3:GetObject",
+				Resource: "arn:aws:s3:::bucket1/a",
+				Context:  tc.context,
+			}, conditionPolicy(tc.operator, tc.key, tc.values...))
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, result.Decision.Allowed())
+		})
+	}
+}
+
+func TestConditionErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		operator string
+		values   []string
+		context  []string
+		err      error
+	}{
+		"unsupported":     {"StringMatches", []string{"a"}, []string{"a"}, policyeval.ErrUnsupportedOperator},
+		"numeric policy":  {"NumericEquals", []string{"ten"}, []string{"10"}, policyeval.ErrInvalidConditionValue},
+		"numeric request": {"NumericEquals", []string{"10"}, []string{"ten"}, policyeval.ErrInvalidConditionValue},
+		"date":            {"DateEquals", []string{"yesterday"}, []string{"2023-01-01"}, policyeval.ErrInvalidConditionValue},
+		"ip":              {"IpAddress", []string{"10.0.0.0/33"}, []string{"10.0.0.1"}, policyeval.ErrInvalidConditionValue},
+		"null values":     {"Null", []string{"true", "false"}, nil, policyeval.ErrInvalidConditionValue},
+		"null bool":       {"Null", []string{"maybe"}, nil, policyeval.ErrInvalidConditionValue},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := policyeval.Evaluate(policyeval.Request{
+				Action:   "s3:GetObject",
+				Resource: "arn:aws:s3:::bucket1/a",
+				Context:  map[string][]string{"k": tc.context},
+			}, conditionPolicy(tc.operator, "k", tc.values...))
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policyeval evaluates requests against IAM identity policies and
+// bucket policies offline, following the AWS evaluation logic: an explicit
+// deny overrides any allow, and requests not explicitly allowed are denied.
+//
+// Example test of a bucket policy before applying it.
+//
+//	func ExampleEvaluate(doc string) {
+//		policy, _ := policyeval.BucketPolicy("bucket1", doc) // e.g. from BucketsInterface.GetPolicy
+//		result, _ := policyeval.Evaluate(policyeval.Request{
+//			Principal: "urn:ecs:iam::ns1:user/user1",
+//			Action:    "s3:GetObject",
+//			Resource:  model.BucketARN("bucket1") + "/report.csv",
+//			Context:   map[string][]string{"aws:SourceIp": {"10.0.0.1"}},
+//		}, policy)
+//		fmt.Println(result.Decision, result.Sid())
+//	}
+package policyeval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// Decision is the outcome of an evaluation.
+type Decision string
+
+// Decisions.
+const (
+	// Allow is returned when a statement allows the request and none denies it.
+	Allow Decision = "Allow"
+
+	// ExplicitDeny is returned when a statement denies the request.
+	ExplicitDeny Decision = "ExplicitDeny"
+
+	// ImplicitDeny is returned when no statement applies to the request.
+	ImplicitDeny Decision = "ImplicitDeny"
+)
+
+// Allowed reports whether the decision allows the request.
+func (d Decision) Allowed() bool {
+	return d == Allow
+}
+
+// Kind is the kind of a policy.
+type Kind string
+
+// Policy kinds.
+const (
+	// Identity policies are attached to users, groups and roles, and apply to
+	// the principal they are attached to.
+	Identity Kind = "Identity"
+
+	// Resource policies are attached to buckets, and apply to the principals
+	// of their statements.
+	Resource Kind = "Resource"
+)
+
+// ErrInvalidPrincipal is returned for policies whose statements have principal
+// elements not allowed for the kind of the policy: identity policies must have
+// none, and resource policies exactly one of Principal and NotPrincipal.
+var ErrInvalidPrincipal = errors.New("invalid principal")
+
+// Policy is a policy taking part in an evaluation.
+type Policy struct {
+	// Name identifies the policy in results, e.g. the policy or bucket name
+	Name string
+
+	// Kind is the kind of the policy
+	Kind Kind
+
+	// Document is the parsed policy document
+	Document model.BucketPolicy
+}
+
+// IdentityPolicy parses the JSON document of an IAM identity policy. Its
+// statements must not have principal elements.
+func IdentityPolicy(name, doc string) (Policy, error) {
+	return parse(name, Identity, doc)
+}
+
+// BucketPolicy parses the JSON document of a bucket policy, as returned by
+// BucketsInterface.GetPolicy. Each of its statements must have either a
+// Principal or a NotPrincipal element.
+func BucketPolicy(name, doc string) (Policy, error) {
+	return parse(name, Resource, doc)
+}
+
+func parse(name string, kind Kind, doc string) (Policy, error) {
+	document, err := model.ParseBucketPolicy(doc)
+	if err != nil {
+		return Policy{}, fmt.Errorf("policy %s: %w", name, err)
+	}
+
+	for i, s := range document.Statement {
+		if err := checkPrincipal(kind, s); err != nil {
+			return Policy{}, fmt.Errorf("policy %s: statement %d: %w", name, i, err)
+		}
+	}
+
+	return Policy{Name: name, Kind: kind, Document: *document}, nil
+}
+
+// checkPrincipal checks the principal elements of a statement of a policy of
+// the kind.
+func checkPrincipal(kind Kind, s model.PolicyStatement) error {
+	switch {
+	case kind == Identity && (s.Principal != nil || s.NotPrincipal != nil):
+		return fmt.Errorf("%w: principal element in identity policy", ErrInvalidPrincipal)
+	case kind == Resource && s.Principal != nil && s.NotPrincipal != nil:
+		return fmt.Errorf("%w: both Principal and NotPrincipal set", ErrInvalidPrincipal)
+	case kind == Resource && s.Principal == nil && s.NotPrincipal == nil:
+		return fmt.Errorf("%w: no principal", ErrInvalidPrincipal)
+	}
+
+	return nil
+}
+
+// Request is the request to evaluate.
+type Request struct {
+	// Principal is the user or role making the request, matched against the
+	// principals of resource policies
+	Principal string
+
+	// PrincipalType is the type of the principal, e.g. model.PrincipalService;
+	// model.PrincipalAWS if empty
+	PrincipalType string
+
+	// Action is the action requested, e.g. "s3:GetObject"
+	Action string
+
+	// Resource is the ARN of the resource, e.g. "arn:aws:s3:::bucket1/key"
+	Resource string
+
+	// Context are the values of the condition keys, e.g. "aws:SourceIp".
+	// Keys are case-insensitive
+	Context map[string][]string
+}
+
+// Result is the result of an evaluation.
+type Result struct {
+	// Decision is the outcome of the evaluation
+	Decision Decision
+
+	// Policy is the name of the policy holding the deciding statement, empty
+	// for ImplicitDeny
+	Policy string
+
+	// Index is the index of the deciding statement in its policy, -1 for
+	// ImplicitDeny
+	Index int
+
+	// Statement is the deciding statement, nil for ImplicitDeny
+	Statement *model.PolicyStatement
+}
+
+// Sid returns the identifier of the deciding statement, if any.
+func (r Result) Sid() string {
+	if r.Statement == nil {
+		return ""
+	}
+
+	return r.Statement.Sid
+}
+
+// String returns the decision and the deciding statement.
+func (r Result) String() string {
+	if r.Statement == nil {
+		return string(r.Decision)
+	}
+
+	return fmt.Sprintf("%s by statement %d (%q) of policy %s", r.Decision, r.Index, r.Statement.Sid, r.Policy)
+}
+
+// Evaluate evaluates the request against the policies. The first statement
+// denying the request decides it; otherwise the first statement allowing it;
+// otherwise the request is implicitly denied. Identity and resource policies
+// are combined as for principals of the account owning the bucket, where an
+// allow in either kind is enough.
+//
+// An error is returned if a statement that applies to the request uses an
+// unsupported condition operator or invalid condition values.
+func Evaluate(req Request, policies ...Policy) (Result, error) {
+	req.Context = normalizeContext(req.Context)
+
+	allow := Result{Decision: ImplicitDeny, Index: -1}
+
+	for _, policy := range policies {
+		for i := range policy.Document.Statement {
+			s := &policy.Document.Statement[i]
+
+			ok, err := applies(req, policy.Kind, s)
+			if err != nil {
+				return Result{}, fmt.Errorf("policy %s: statement %d: %w", policy.Name, i, err)
+			}
+
+			if !ok {
+				continue
+			}
+
+			switch s.Effect {
+			case model.EffectDeny:
+				return Result{Decision: ExplicitDeny, Policy: policy.Name, Index: i, Statement: s}, nil
+			case model.EffectAllow:
+				if allow.Statement == nil {
+					allow = Result{Decision: Allow, Policy: policy.Name, Index: i, Statement: s}
+				}
+			}
+		}
+	}
+
+	return allow, nil
+}
+
+// applies reports whether the statement applies to the request.
+func applies(req Request, kind Kind, s *model.PolicyStatement) (bool, error) {
+	if kind == Resource && !matchPrincipal(s, req) {
+		return false, nil
+	}
+
+	if !matchAny(s.Action, s.NotAction, req.Action, true) {
+		return false, nil
+	}
+
+	if !matchAny(s.Resource, s.NotResource, req.Resource, false) {
+		return false, nil
+	}
+
+	return matchConditions(s.Condition, req.Context)
+}
+
+// matchPrincipal reports whether the principal of the request is one of the
+// principals of the statement, or none of the negated principals if those are
+// set instead.
+func matchPrincipal(s *model.PolicyStatement, req Request) bool {
+	switch {
+	case s.Principal != nil:
+		return isPrincipal(*s.Principal, req)
+	case s.NotPrincipal != nil:
+		return !isPrincipal(*s.NotPrincipal, req)
+	}
+
+	return false
+}
+
+// isPrincipal reports whether the principal of the request is one of the
+// principals of its type.
+func isPrincipal(principal model.PolicyPrincipal, req Request) bool {
+	if principal.Any {
+		return true
+	}
+
+	principalType := req.PrincipalType
+	if principalType == "" {
+		principalType = model.PrincipalAWS
+	}
+
+	for _, p := range principal.Types()[principalType] {
+		if wildcardMatch(p, req.Principal, false) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAny reports whether the value matches one of the patterns, or none of
+// the negated patterns if those are set instead.
+func matchAny(patterns, notPatterns model.StringList, value string, ignoreCase bool) bool {
+	if len(notPatterns) != 0 {
+		for _, p := range notPatterns {
+			if wildcardMatch(p, value, ignoreCase) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for _, p := range patterns {
+		if wildcardMatch(p, value, ignoreCase) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wildcardMatch reports whether the value matches the pattern, where "*"
+// matches any sequence of characters, including "/", and "?" matches any
+// single character.
+func wildcardMatch(pattern, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	}
+
+	p, v := []rune(pattern), []rune(value)
+
+	// Backtrack to the last "*" on mismatch.
+	pi, vi, star, mark := 0, 0, -1, 0
+
+	for vi < len(v) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == v[vi]):
+			pi++
+			vi++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, vi
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			vi = mark
+		default:
+			return false
+		}
+	}
+
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+
+	return pi == len(p)
+}
@@ -0,0 +1,286 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyeval_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/policyeval"
+)
+
+const bucketPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Sid": "readers",
+			"Effect": "Allow",
+			"Principal": {"AWS": ["urn:ecs:iam::ns1:user/reader", "urn:ecs:iam::ns1:role/*"]},
+			"Action": ["s3:GetObject", "s3:ListBucket"],
+			"Resource": ["arn:aws:s3:::bucket1", "arn:aws:s3:::bucket1/*"]
+		},
+		{
+			"Sid": "no-secrets",
+			"Effect": "Deny",
+			"Principal": "*",
+			"Action": "s3:*",
+			"Resource": "arn:aws:s3:::bucket1/secret/*"
+		},
+		{
+			"Sid": "public-prefix",
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::bucket1/public/*"
+		}
+	]
+}`
+
+const identityPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": {
+		"Sid": "writer",
+		"Effect": "Allow",
+		"Action": "s3:Put*",
+		"NotResource": "arn:aws:s3:::bucket1/readonly/*"
+	}
+}`
+
+func TestPolicyEval(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Decisions":     testDecisions,
+		"Combined":      testCombined,
+		"Principals":    testPrincipals,
+		"Parse":         testParse,
+		"WildcardMatch": testWildcardMatch,
+		"Result":        testResult,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testDecisions(t *testing.T) {
+	policy, err := policyeval.BucketPolicy("bucket1", bucketPolicy)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		req      policyeval.Request
+		decision policyeval.Decision
+		sid      string
+	}{
+		"allowed user": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/reader", Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/a/b.txt"},
+			decision: policyeval.Allow,
+			sid:      "readers",
+		},
+		"allowed role by wildcard": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:role/app", Action: "s3:ListBucket", Resource: "arn:aws:s3:::bucket1"},
+			decision: policyeval.Allow,
+			sid:      "readers",
+		},
+		"action is case insensitive": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/reader", Action: "S3:getobject", Resource: "arn:aws:s3:::bucket1/a"},
+			decision: policyeval.Allow,
+			sid:      "readers",
+		},
+		"explicit deny overrides allow": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/reader", Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/secret/key"},
+			decision: policyeval.ExplicitDeny,
+			sid:      "no-secrets",
+		},
+		"anonymous public prefix": {
+			req:      policyeval.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/public/index.html"},
+			decision: policyeval.Allow,
+			sid:      "public-prefix",
+		},
+		"other user": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/other", Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/a"},
+			decision: policyeval.ImplicitDeny,
+		},
+		"other action": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/reader", Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/a"},
+			decision: policyeval.ImplicitDeny,
+		},
+		"other bucket": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/reader", Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket10/a"},
+			decision: policyeval.ImplicitDeny,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := policyeval.Evaluate(tc.req, policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.decision, result.Decision)
+			assert.Equal(t, tc.sid, result.Sid())
+			assert.Equal(t, tc.decision == policyeval.Allow, result.Decision.Allowed())
+		})
+	}
+}
+
+func testCombined(t *testing.T) {
+	bucket, err := policyeval.BucketPolicy("bucket1", bucketPolicy)
+	require.NoError(t, err)
+
+	identity, err := policyeval.IdentityPolicy("writer-policy", identityPolicy)
+	require.NoError(t, err)
+
+	// The identity policy applies to the principal it is attached to.
+	result, err := policyeval.Evaluate(policyeval.Request{
+		Principal: "urn:ecs:iam::ns1:user/writer", Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/a",
+	}, bucket, identity)
+	require.NoError(t, err)
+	assert.Equal(t, policyeval.Allow, result.Decision)
+	assert.Equal(t, "writer-policy", result.Policy)
+	assert.Equal(t, 0, result.Index)
+
+	result, err = policyeval.Evaluate(policyeval.Request{
+		Principal: "urn:ecs:iam::ns1:user/writer", Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/readonly/a",
+	}, bucket, identity)
+	require.NoError(t, err)
+	assert.Equal(t, policyeval.ImplicitDeny, result.Decision)
+
+	// A deny of the bucket policy overrides the allow of the identity policy.
+	result, err = policyeval.Evaluate(policyeval.Request{
+		Principal: "urn:ecs:iam::ns1:user/writer", Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/secret/a",
+	}, identity, bucket)
+	require.NoError(t, err)
+	assert.Equal(t, policyeval.ExplicitDeny, result.Decision)
+	assert.Equal(t, "bucket1", result.Policy)
+	assert.Equal(t, 1, result.Index)
+
+	// No policies deny implicitly.
+	result, err = policyeval.Evaluate(policyeval.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/a"})
+	require.NoError(t, err)
+	assert.Equal(t, policyeval.ImplicitDeny, result.Decision)
+	assert.Equal(t, -1, result.Index)
+}
+
+func testPrincipals(t *testing.T) {
+	policy, err := policyeval.BucketPolicy("bucket1", `{"Statement": [{
+		"Sid": "logs",
+		"Effect": "Allow",
+		"Principal": {"Service": "logging.s3.amazonaws.com"},
+		"Action": "s3:PutObject",
+		"Resource": "arn:aws:s3:::bucket1/logs/*"
+	}, {
+		"Sid": "admin-only",
+		"Effect": "Deny",
+		"NotPrincipal": {"AWS": "urn:ecs:iam::ns1:user/admin"},
+		"Action": "s3:DeleteBucket",
+		"Resource": "arn:aws:s3:::bucket1"
+	}]}`)
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		req      policyeval.Request
+		decision policyeval.Decision
+	}{
+		"service principal": {
+			req: policyeval.Request{
+				Principal: "logging.s3.amazonaws.com", PrincipalType: model.PrincipalService,
+				Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/logs/a",
+			},
+			decision: policyeval.Allow,
+		},
+		"user named as service": {
+			req:      policyeval.Request{Principal: "logging.s3.amazonaws.com", Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket1/logs/a"},
+			decision: policyeval.ImplicitDeny,
+		},
+		"not principal excluded": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/admin", Action: "s3:DeleteBucket", Resource: "arn:aws:s3:::bucket1"},
+			decision: policyeval.ImplicitDeny,
+		},
+		"not principal applies": {
+			req:      policyeval.Request{Principal: "urn:ecs:iam::ns1:user/other", Action: "s3:DeleteBucket", Resource: "arn:aws:s3:::bucket1"},
+			decision: policyeval.ExplicitDeny,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := policyeval.Evaluate(tc.req, policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.decision, result.Decision)
+		})
+	}
+
+	_, err = policyeval.IdentityPolicy("with-principal", `{"Statement":{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}}`)
+	require.ErrorIs(t, err, policyeval.ErrInvalidPrincipal)
+
+	_, err = policyeval.BucketPolicy("no-principal", `{"Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`)
+	require.ErrorIs(t, err, policyeval.ErrInvalidPrincipal)
+	assert.ErrorContains(t, err, "policy no-principal: statement 0")
+}
+
+func testParse(t *testing.T) {
+	policy, err := policyeval.BucketPolicy("empty", "")
+	require.NoError(t, err)
+	assert.Equal(t, policyeval.Resource, policy.Kind)
+	assert.Empty(t, policy.Document.Statement)
+
+	_, err = policyeval.IdentityPolicy("broken", "{")
+	require.ErrorContains(t, err, "policy broken")
+
+	// Statements with unsupported operators report an error when they apply.
+	policy, err = policyeval.IdentityPolicy("odd", `{"Statement":{"Effect":"Allow","Action":"s3:GetObject",`+
+		`"Resource":"*","Condition":{"Matches":{"k":"v"}}}}`)
+	require.NoError(t, err)
+
+	_, err = policyeval.Evaluate(policyeval.Request{Action: "s3:PutObject", Resource: "arn:aws:s3:::b"}, policy)
+	require.NoError(t, err)
+
+	_, err = policyeval.Evaluate(policyeval.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::b"}, policy)
+	require.ErrorIs(t, err, policyeval.ErrUnsupportedOperator)
+	assert.ErrorContains(t, err, "policy odd: statement 0")
+}
+
+func testWildcardMatch(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resource string
+		pattern  string
+		match    bool
+	}{
+		"exact":          {"arn:aws:s3:::b/k", "arn:aws:s3:::b/k", true},
+		"star spans /":   {"arn:aws:s3:::b/a/b/c", "arn:aws:s3:::b/*", true},
+		"star empty":     {"arn:aws:s3:::b/", "arn:aws:s3:::b/*", true},
+		"star middle":    {"arn:aws:s3:::b/home/u1/file", "arn:aws:s3:::b/home/*/file", true},
+		"star backtrack": {"arn:aws:s3:::b/a-x-y", "arn:aws:s3:::b/*-y", true},
+		"question":       {"arn:aws:s3:::b/k1", "arn:aws:s3:::b/k?", true},
+		"question one":   {"arn:aws:s3:::b/k12", "arn:aws:s3:::b/k?", false},
+		"case sensitive": {"arn:aws:s3:::b/K", "arn:aws:s3:::b/k", false},
+		"prefix only":    {"arn:aws:s3:::b/k", "arn:aws:s3:::b", false},
+		"any":            {"arn:aws:s3:::b/k", "*", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := policyeval.IdentityPolicy(name, `{"Statement":{"Effect":"Allow","Action":"*","Resource":"`+tc.pattern+`"}}`)
+			require.NoError(t, err)
+
+			result, err := policyeval.Evaluate(policyeval.Request{Action: "s3:GetObject", Resource: tc.resource}, policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, result.Decision.Allowed())
+		})
+	}
+}
+
+func testResult(t *testing.T) {
+	policy, err := policyeval.BucketPolicy("bucket1", bucketPolicy)
+	require.NoError(t, err)
+
+	result, err := policyeval.Evaluate(policyeval.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket1/secret/a"}, policy)
+	require.NoError(t, err)
+	assert.Equal(t, `ExplicitDeny by statement 1 ("no-secrets") of policy bucket1`, result.String())
+
+	result, err = policyeval.Evaluate(policyeval.Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::bucket2/a"}, policy)
+	require.NoError(t, err)
+	assert.Equal(t, "ImplicitDeny", result.String())
+}
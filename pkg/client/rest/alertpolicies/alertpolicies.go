@@ -116,3 +116,37 @@ func (ap *AlertPolicies) Update(ctx context.Context, payload model.AlertPolicy,
 
 	return alertpolicy, nil
 }
+
+// Apply implements the AlertPolicy interface.
+func (ap *AlertPolicies) Apply(ctx context.Context, payload model.AlertPolicy) (*model.AlertPolicy, model.ApplyResult, error) {
+	live, err := ap.Get(ctx, payload.PolicyName)
+	if model.IsNotFound(err) {
+		created, createErr := ap.Create(ctx, payload)
+		if createErr == nil {
+			return created, model.ApplyCreated, nil
+		}
+
+		// no error code is known for an existing alert policy, so it may have
+		// been created concurrently: compare with what is there now, if anything
+		live, err = ap.Get(ctx, payload.PolicyName)
+		if model.IsNotFound(err) {
+			return nil, "", createErr
+		}
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	merged, changed := payload.Merge(*live)
+	if !changed {
+		return live, model.ApplyUnchanged, nil
+	}
+
+	updated, err := ap.Update(ctx, merged, payload.PolicyName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updated, model.ApplyUpdated, nil
+}
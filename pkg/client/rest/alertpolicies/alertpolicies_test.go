@@ -71,6 +71,7 @@ func TestAlertpolicies(t *testing.T) {
 		"create": testCreate,
 		"update": testUpdate,
 		"delete": testDelete,
+		"apply":  testApply,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, clientset)
@@ -123,3 +124,35 @@ func testDelete(t *testing.T, clientset *rest.ClientSet) {
 	err = clientset.AlertPolicies().Delete(context.TODO(), "")
 	require.Error(t, err)
 }
+
+func testApply(t *testing.T, clientset *rest.ClientSet) {
+	payload := model.AlertPolicy{
+		PolicyName: "applyPolicy1",
+		MetricType: "Geo Replication Statistics",
+		MetricName: "RPO",
+		Condition: model.AlertPolicyCondition{
+			ThresholdUnits: "HOURS",
+			ThresholdValue: "1",
+		},
+	}
+
+	_, result, err := clientset.AlertPolicies().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyCreated, result)
+
+	payload.PolicyName = "applyPolicy2"
+	payload.Condition.ThresholdValue = "2"
+
+	policy, result, err := clientset.AlertPolicies().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUpdated, result)
+	assert.Equal(t, "2", policy.Condition.ThresholdValue)
+	assert.Equal(t, "WARNING", policy.Condition.SeverityType)
+
+	payload.PolicyName = "applyPolicy3"
+	payload.Condition.ThresholdValue = "1"
+
+	_, result, err = clientset.AlertPolicies().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUnchanged, result)
+}
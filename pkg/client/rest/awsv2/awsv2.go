@@ -0,0 +1,167 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awsv2 configures aws-sdk-go-v2 clients to connect with ObjectScale.
+//
+// It is the aws-sdk-go-v2 counterpart of iam.InjectTokenToIAMClient and
+// iam.InjectAccountIDToIAMClient. It is tested with IAM and STS clients.
+//
+// Example function using an IAM client to create new user in ObjectScale.
+//
+//	func ExampleCreateIAMUser(userName string) {
+//		objClient := client.AuthUser{Gateway: "https://testgateway", Username: "username", Password: "password"}
+//		iamClient := iam.New(iam.Options{ // github.com/aws/aws-sdk-go-v2/service/iam
+//			BaseEndpoint: aws.String("https://testgateway/iam"),
+//			Region:       "us-west-2",
+//			HTTPClient:   http.DefaultClient,
+//			APIOptions:   awsv2.APIOptions(&objClient, http.DefaultClient, "osaid185e2bf9e8ae35f"),
+//		})
+//		user, err := iamClient.CreateUser(context.TODO(), &iam.CreateUserInput{
+//			UserName: aws.String(userName),
+//		})
+//	}
+package awsv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+	"github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+// IDs of the middlewares added to the Finalize step.
+const (
+	TokenMiddlewareID     = iam.SDSHandlerName
+	AccountIDMiddlewareID = iam.AccountIDHandlerName
+)
+
+// IDs of the SigV4 middlewares of the Finalize step replaced by the token
+// middleware.
+const (
+	SigningMiddlewareID     = "Signing"
+	GetIdentityMiddlewareID = "GetIdentity"
+)
+
+// APIOptions returns the options authenticating requests with the token of the
+// authenticator and, if accountID is not empty, acting on the account. They
+// are meant to be appended to the APIOptions of the client options.
+func APIOptions(auth client.Authenticator, httpClient *http.Client, accountID string) []func(*middleware.Stack) error {
+	options := []func(*middleware.Stack) error{WithToken(auth, httpClient)}
+
+	if accountID != "" {
+		options = append(options, WithAccountID(accountID))
+	}
+
+	return options
+}
+
+// WithToken replaces SigV4 signing with the ObjectScale token of the
+// authenticator, sent in the X-Sds-Auth-Token header. The authenticator logs in
+// with httpClient when it is not authenticated yet. Requests rejected with 401
+// Unauthorized are sent once more after a new login.
+func WithToken(auth client.Authenticator, httpClient *http.Client) func(*middleware.Stack) error {
+	m := middleware.FinalizeMiddlewareFunc(TokenMiddlewareID, func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+	) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("unexpected transport type %T", in.Request)
+		}
+
+		if !auth.IsAuthenticated() {
+			if err := auth.Login(ctx, httpClient); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+		}
+
+		req.Header.Set(iam.SDSHeaderName, auth.Token())
+
+		out, metadata, err := next.HandleFinalize(ctx, in)
+		if !unauthorized(err) {
+			return out, metadata, err
+		}
+
+		// The token expired or was revoked: log in again and send the request
+		// with the new token.
+		if err := auth.Login(ctx, httpClient); err != nil {
+			return out, metadata, err
+		}
+
+		if err := req.RewindStream(); err != nil {
+			return out, metadata, err
+		}
+
+		req.Header.Set(iam.SDSHeaderName, auth.Token())
+
+		return next.HandleFinalize(ctx, in)
+	})
+
+	return func(stack *middleware.Stack) error {
+		// Identity is only resolved for SigV4 signing.
+		if _, ok := stack.Finalize.Get(GetIdentityMiddlewareID); ok {
+			if _, err := stack.Finalize.Remove(GetIdentityMiddlewareID); err != nil {
+				return err
+			}
+		}
+
+		return setFinalize(stack, m, SigningMiddlewareID)
+	}
+}
+
+// WithAccountID sets the ObjectScale account the requests act on, sent in the
+// X-Emc-Namespace header.
+func WithAccountID(accountID string) func(*middleware.Stack) error {
+	m := middleware.FinalizeMiddlewareFunc(AccountIDMiddlewareID, func(
+		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+	) (middleware.FinalizeOutput, middleware.Metadata, error) {
+		req, ok := in.Request.(*smithyhttp.Request)
+		if !ok {
+			return middleware.FinalizeOutput{}, middleware.Metadata{}, fmt.Errorf("unexpected transport type %T", in.Request)
+		}
+
+		req.Header.Set(iam.AccountIDHeaderName, accountID)
+
+		return next.HandleFinalize(ctx, in)
+	})
+
+	return func(stack *middleware.Stack) error {
+		return setFinalize(stack, m, "")
+	}
+}
+
+// unauthorized reports whether err is a 401 Unauthorized response.
+func unauthorized(err error) bool {
+	var respErr *smithyhttp.ResponseError
+
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusUnauthorized
+}
+
+// setFinalize adds the middleware to the Finalize step. A middleware with the
+// same ID, or else the one with the replaced ID, is swapped in place; otherwise
+// the middleware is added last, so it runs on every retry attempt.
+func setFinalize(stack *middleware.Stack, m middleware.FinalizeMiddleware, replaced string) error {
+	for _, id := range []string{m.ID(), replaced} {
+		if _, ok := stack.Finalize.Get(id); ok && id != "" {
+			_, err := stack.Finalize.Swap(id, m)
+
+			return err
+		}
+	}
+
+	return stack.Finalize.Add(m, middleware.After)
+}
@@ -0,0 +1,219 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsv2_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/awsv2"
+	objscaleIAM "github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+// fakeAuth is an authenticator counting logins.
+type fakeAuth struct {
+	logins int
+	err    error
+}
+
+func (a *fakeAuth) IsAuthenticated() bool { return a.logins > 0 }
+
+func (a *fakeAuth) Login(context.Context, *http.Client) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	a.logins++
+
+	return nil
+}
+
+func (a *fakeAuth) Token() string { return "TESTTOKEN" }
+
+// recordingClient answers every request with body and records the last one.
+func recordingClient(last **http.Request, body string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) *http.Response {
+		*last = req
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/xml"}},
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}
+	})}
+}
+
+type roundTripFunc func(req *http.Request) *http.Response
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func TestAWSv2(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"IAM":        testIAM,
+		"STS":        testSTS,
+		"LoginError": testLoginError,
+		"Relogin":    testRelogin,
+		"Stack":      testStack,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testIAM(t *testing.T) {
+	var last *http.Request
+
+	auth := &fakeAuth{}
+	httpClient := recordingClient(&last, `<GetUserResponse><GetUserResult><User><UserName>user1</UserName></User></GetUserResult></GetUserResponse>`)
+
+	client := iam.New(iam.Options{
+		BaseEndpoint: aws.String("https://testgateway/iam"),
+		Region:       "us-west-2",
+		HTTPClient:   httpClient,
+		APIOptions:   awsv2.APIOptions(auth, httpClient, "osai1"),
+	})
+
+	out, err := client.GetUser(context.TODO(), &iam.GetUserInput{UserName: aws.String("user1")})
+	require.NoError(t, err)
+	assert.Equal(t, "user1", aws.ToString(out.User.UserName))
+
+	assert.Equal(t, "TESTTOKEN", last.Header.Get(objscaleIAM.SDSHeaderName))
+	assert.Equal(t, "osai1", last.Header.Get(objscaleIAM.AccountIDHeaderName))
+	assert.Empty(t, last.Header.Get("Authorization"))
+	assert.Equal(t, 1, auth.logins)
+
+	// The authenticator only logs in once.
+	_, err = client.GetUser(context.TODO(), &iam.GetUserInput{UserName: aws.String("user1")})
+	require.NoError(t, err)
+	assert.Equal(t, 1, auth.logins)
+}
+
+func testSTS(t *testing.T) {
+	var last *http.Request
+
+	auth := &fakeAuth{}
+	httpClient := recordingClient(&last, `<GetCallerIdentityResponse><GetCallerIdentityResult><Account>osai1</Account>`+
+		`</GetCallerIdentityResult></GetCallerIdentityResponse>`)
+
+	client := sts.New(sts.Options{
+		BaseEndpoint: aws.String("https://testgateway/sts"),
+		Region:       "us-west-2",
+		HTTPClient:   httpClient,
+		APIOptions:   awsv2.APIOptions(auth, httpClient, ""),
+	})
+
+	out, err := client.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "osai1", aws.ToString(out.Account))
+	assert.Equal(t, "TESTTOKEN", last.Header.Get(objscaleIAM.SDSHeaderName))
+	assert.Empty(t, last.Header.Get(objscaleIAM.AccountIDHeaderName))
+	assert.Empty(t, last.Header.Get("Authorization"))
+}
+
+func testLoginError(t *testing.T) {
+	var last *http.Request
+
+	errLogin := errors.New("login failed")
+	httpClient := recordingClient(&last, "")
+
+	client := iam.New(iam.Options{
+		BaseEndpoint: aws.String("https://testgateway/iam"),
+		Region:       "us-west-2",
+		HTTPClient:   httpClient,
+		APIOptions:   awsv2.APIOptions(&fakeAuth{err: errLogin}, httpClient, "osai1"),
+	})
+
+	_, err := client.GetUser(context.TODO(), &iam.GetUserInput{UserName: aws.String("user1")})
+	require.ErrorIs(t, err, errLogin)
+	assert.Nil(t, last)
+}
+
+func testRelogin(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rejected int
+		requests int
+		err      bool
+	}{
+		"expired": {rejected: 1, requests: 2},
+		"revoked": {rejected: 3, requests: 2, err: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			httpClient := &http.Client{Transport: roundTripFunc(func(*http.Request) *http.Response {
+				requests++
+
+				if requests <= tc.rejected {
+					return &http.Response{
+						StatusCode: http.StatusUnauthorized,
+						Header:     http.Header{"Content-Type": []string{"text/xml"}},
+						Body:       io.NopCloser(bytes.NewBufferString(`<ErrorResponse><Error><Code>Unauthorized</Code></Error></ErrorResponse>`)),
+					}
+				}
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/xml"}},
+					Body:       io.NopCloser(bytes.NewBufferString(`<GetUserResponse><GetUserResult><User><UserName>user1</UserName></User></GetUserResult></GetUserResponse>`)),
+				}
+			})}
+
+			auth := &fakeAuth{}
+			client := iam.New(iam.Options{
+				BaseEndpoint: aws.String("https://testgateway/iam"),
+				Region:       "us-west-2",
+				HTTPClient:   httpClient,
+				APIOptions:   awsv2.APIOptions(auth, httpClient, "osai1"),
+			})
+
+			_, err := client.GetUser(context.TODO(), &iam.GetUserInput{UserName: aws.String("user1")})
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.requests, requests)
+			assert.Equal(t, 2, auth.logins)
+		})
+	}
+}
+
+func testStack(t *testing.T) {
+	noop := middleware.FinalizeMiddlewareFunc("", nil)
+	stack := middleware.NewStack("test", nil)
+
+	for _, id := range []string{"Retry", awsv2.GetIdentityMiddlewareID, awsv2.SigningMiddlewareID, "Last"} {
+		require.NoError(t, stack.Finalize.Add(middleware.FinalizeMiddlewareFunc(id, noop.HandleFinalize), middleware.After))
+	}
+
+	// Options applied twice replace the middlewares in place.
+	for i := 0; i < 2; i++ {
+		for _, fn := range awsv2.APIOptions(&fakeAuth{}, nil, "osai1") {
+			require.NoError(t, fn(stack))
+		}
+	}
+
+	assert.Equal(t, []string{"Retry", awsv2.TokenMiddlewareID, "Last", awsv2.AccountIDMiddlewareID}, stack.Finalize.List())
+}
@@ -15,17 +15,26 @@ package buckets
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
 
+	"github.com/dell/goobjectscale/pkg/client/api"
 	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/dell/goobjectscale/pkg/client/rest/client"
 )
 
+// ErrNoTenants is returned by UpdateRetention if Buckets.Tenants is not set.
+var ErrNoTenants = errors.New("tenants client not set")
+
 // Buckets is a REST implementation of the Buckets interface.
 type Buckets struct {
 	Client client.RemoteCaller
+
+	// Tenants is used by UpdateRetention to check the compliance of the tenant
+	// owning the bucket
+	Tenants api.TenantsInterface
 }
 
 // Get implements the buckets interface.
@@ -98,6 +107,25 @@ func (b *Buckets) UpdatePolicy(ctx context.Context, bucketName string, policy st
 	return b.Client.MakeRemoteCall(ctx, req, nil)
 }
 
+// GetPolicyDocument implements the buckets interface.
+func (b *Buckets) GetPolicyDocument(ctx context.Context, bucketName string, param map[string]string) (*model.BucketPolicy, error) {
+	policy, err := b.GetPolicy(ctx, bucketName, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ParseBucketPolicy(policy)
+}
+
+// UpdatePolicyDocument implements the buckets interface.
+func (b *Buckets) UpdatePolicyDocument(ctx context.Context, bucketName string, policy model.BucketPolicy, param map[string]string) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	return b.UpdatePolicy(ctx, bucketName, policy.String(), param)
+}
+
 // DeletePolicy implements the buckets interface.
 func (b *Buckets) DeletePolicy(ctx context.Context, bucketName string, param map[string]string) error {
 	req := client.Request{
@@ -112,6 +140,10 @@ func (b *Buckets) DeletePolicy(ctx context.Context, bucketName string, param map
 
 // Create implements the buckets interface.
 func (b *Buckets) Create(ctx context.Context, createParam model.Bucket) (*model.Bucket, error) {
+	if err := createParam.SearchMetadata.ValidateSearchMetadata(); err != nil {
+		return nil, err
+	}
+
 	req := client.Request{
 		Method:      http.MethodPost,
 		Path:        "/object/bucket",
@@ -145,6 +177,74 @@ func (b *Buckets) Delete(ctx context.Context, name string, namespace string, emp
 	return nil
 }
 
+// DisableSearchMetadata implements the buckets interface.
+func (b *Buckets) DisableSearchMetadata(ctx context.Context, bucketName string, namespace string) error {
+	req := client.Request{
+		Method:      http.MethodDelete,
+		Path:        fmt.Sprintf("object/bucket/%s/searchmetadata", bucketName),
+		ContentType: client.ContentTypeXML,
+		Params:      map[string]string{"namespace": namespace},
+	}
+
+	return b.Client.MakeRemoteCall(ctx, req, nil)
+}
+
+// GetACL implements the buckets interface.
+func (b *Buckets) GetACL(ctx context.Context, bucketName string, namespace string) (*model.BucketACL, error) {
+	req := client.Request{
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf("object/bucket/%s/acl", bucketName),
+		ContentType: client.ContentTypeXML,
+		Params:      map[string]string{"namespace": namespace},
+	}
+	acl := &model.BucketACL{}
+
+	err := b.Client.MakeRemoteCall(ctx, req, acl)
+	if err != nil {
+		return nil, err
+	}
+
+	return acl, nil
+}
+
+// SetACL implements the buckets interface.
+func (b *Buckets) SetACL(ctx context.Context, acl model.BucketACL) error {
+	if err := acl.Validate(); err != nil {
+		return err
+	}
+
+	req := client.Request{
+		Method:      http.MethodPut,
+		Path:        fmt.Sprintf("object/bucket/%s/acl", acl.Bucket),
+		ContentType: client.ContentTypeXML,
+		Body:        &acl,
+	}
+
+	return b.Client.MakeRemoteCall(ctx, req, nil)
+}
+
+// UpdateDefaultGroup implements the buckets interface.
+func (b *Buckets) UpdateDefaultGroup(ctx context.Context, bucketName string, namespace string, group string, permissions model.DefaultGroupPermissions) error {
+	bucket, err := b.Get(ctx, bucketName, map[string]string{"namespace": namespace})
+	if err != nil {
+		return err
+	}
+
+	if err := model.CheckDefaultGroupUpdate(*bucket, group); err != nil {
+		return err
+	}
+
+	update := model.NewBucketDefaultGroupUpdate(namespace, group, permissions)
+	req := client.Request{
+		Method:      http.MethodPut,
+		Path:        fmt.Sprintf("object/bucket/%s/defaultgroup", bucketName),
+		ContentType: client.ContentTypeXML,
+		Body:        &update,
+	}
+
+	return b.Client.MakeRemoteCall(ctx, req, nil)
+}
+
 // GetQuota gets the quota for the given bucket and namespace.
 func (b *Buckets) GetQuota(ctx context.Context, bucketName string, namespace string) (*model.BucketQuotaInfo, error) {
 	req := client.Request{
@@ -175,6 +275,73 @@ func (b *Buckets) UpdateQuota(ctx context.Context, bucketQuota model.BucketQuota
 	return b.Client.MakeRemoteCall(ctx, req, nil)
 }
 
+// Apply implements the buckets interface.
+func (b *Buckets) Apply(ctx context.Context, bucket model.Bucket) (*model.Bucket, model.ApplyResult, error) {
+	params := map[string]string{}
+	if bucket.Namespace != "" {
+		params["namespace"] = bucket.Namespace
+	}
+
+	live, err := b.Get(ctx, bucket.Name, params)
+	if model.IsNotFound(err) {
+		live, err = b.Create(ctx, bucket)
+		if err == nil {
+			return live, model.ApplyCreated, nil
+		}
+
+		if !model.IsAlreadyExists(err) {
+			return nil, "", err
+		}
+
+		// created concurrently, compare with what is there now
+		live, err = b.Get(ctx, bucket.Name, params)
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := bucket.CheckImmutable(*live); err != nil {
+		return nil, "", err
+	}
+
+	result := model.ApplyUnchanged
+
+	if update, changed := bucket.QuotaUpdate(*live); changed {
+		if err := b.UpdateQuota(ctx, update); err != nil {
+			return nil, "", err
+		}
+
+		live.BlockSize = update.BlockSize
+		live.BlockSizeCount = update.BlockSizeCount
+		live.NotificationSize = update.NotificationSize
+		live.NotificationSizeCount = update.NotificationSizeCount
+		result = model.ApplyUpdated
+	}
+
+	if retention, changed := bucket.RetentionUpdate(*live); changed {
+		if err := b.UpdateRetention(ctx, live.Name, live.Namespace, retention); err != nil {
+			return nil, "", err
+		}
+
+		live.DefaultRetention = retention.DefaultRetention
+		live.MinMaxGovenor = retention.Governor
+		result = model.ApplyUpdated
+	}
+
+	if group, permissions, changed := bucket.DefaultGroupUpdate(*live); changed {
+		if err := b.UpdateDefaultGroup(ctx, live.Name, live.Namespace, group, permissions); err != nil {
+			return nil, "", err
+		}
+
+		live.DefaultGroup = group
+		live.SetDefaultGroupPermissions(permissions)
+		result = model.ApplyUpdated
+	}
+
+	return live, result, nil
+}
+
 // DeleteQuota deletes the quota setting for the given bucket and namespace.
 func (b *Buckets) DeleteQuota(ctx context.Context, bucketName string, namespace string) error {
 	req := client.Request{
@@ -186,3 +353,59 @@ func (b *Buckets) DeleteQuota(ctx context.Context, bucketName string, namespace
 
 	return b.Client.MakeRemoteCall(ctx, req, nil)
 }
+
+// GetRetention implements the buckets interface. The settings are read from
+// the bucket info.
+func (b *Buckets) GetRetention(ctx context.Context, bucketName string, namespace string) (*model.BucketRetention, error) {
+	bucket, err := b.Get(ctx, bucketName, map[string]string{"namespace": namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BucketRetention{
+		DefaultRetention: bucket.DefaultRetention,
+		Governor:         bucket.MinMaxGovenor,
+	}, nil
+}
+
+// UpdateRetention implements the buckets interface.
+func (b *Buckets) UpdateRetention(ctx context.Context, bucketName string, namespace string, retention model.BucketRetention) error {
+	if b.Tenants == nil {
+		return ErrNoTenants
+	}
+
+	current, err := b.GetRetention(ctx, bucketName, namespace)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := b.Tenants.Get(ctx, namespace, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := model.CheckRetentionUpdate(*tenant, *current, retention); err != nil {
+		return err
+	}
+
+	for _, step := range retention.Steps(*current) {
+		req := client.Request{
+			Method:      http.MethodPut,
+			Path:        fmt.Sprintf("object/bucket/%s/retention", bucketName),
+			ContentType: client.ContentTypeXML,
+			Body:        &model.BucketRetentionUpdate{Namespace: namespace, Period: step.Retention.DefaultRetention},
+		}
+
+		if step.Governor {
+			update := model.NewMinMaxGovernorUpdate(namespace, step.Retention.Governor)
+			req.Path = fmt.Sprintf("object/bucket/%s/min-max-governor", bucketName)
+			req.Body = &update
+		}
+
+		if err := b.Client.MakeRemoteCall(ctx, req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
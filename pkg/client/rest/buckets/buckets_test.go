@@ -65,16 +65,23 @@ func TestBuckets(t *testing.T) {
 	clientset := rest.NewClientSet(&c)
 
 	for scenario, fn := range map[string]func(t *testing.T, clientset *rest.ClientSet){
-		"list":         testList,
-		"get":          testGet,
-		"create":       testCreate,
-		"delete":       testDelete,
-		"getQuota":     testGetQuota,
-		"getPolicy":    testGetPolicy,
-		"updatePolicy": testUpdatePolicy,
-		"deletePolicy": testDeletePolicy,
-		"UpdateQuota":  testUpdateQuota,
-		"deleteQuota":  testDeleteQuota,
+		"list":                 testList,
+		"get":                  testGet,
+		"create":               testCreate,
+		"delete":               testDelete,
+		"getQuota":             testGetQuota,
+		"getPolicy":            testGetPolicy,
+		"updatePolicy":         testUpdatePolicy,
+		"deletePolicy":         testDeletePolicy,
+		"getPolicyDocument":    testGetPolicyDocument,
+		"updatePolicyDocument": testUpdatePolicyDocument,
+		"UpdateQuota":          testUpdateQuota,
+		"deleteQuota":          testDeleteQuota,
+		"apply":                testApply,
+		"updateRetention":      testUpdateRetention,
+		"searchMetadata":       testSearchMetadata,
+		"acl":                  testACL,
+		"updateDefaultGroup":   testUpdateDefaultGroup,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, clientset)
@@ -95,6 +102,8 @@ func testGet(t *testing.T, clientset *rest.ClientSet) {
 	bucket, err := clientset.Buckets().Get(context.TODO(), "Files", map[string]string{})
 	require.NoError(t, err)
 	assert.Equal(t, bucket.Name, "Files")
+	assert.False(t, bucket.Enabled)
+	assert.Empty(t, bucket.Metadata)
 
 	_, err = clientset.Buckets().Get(context.TODO(), "Flies", map[string]string{})
 	require.Error(t, err)
@@ -157,6 +166,35 @@ func testUpdatePolicy(t *testing.T, clientset *rest.ClientSet) {
 	require.Nil(t, err)
 }
 
+func testGetPolicyDocument(t *testing.T, clientset *rest.ClientSet) {
+	policy, err := clientset.Buckets().GetPolicyDocument(context.TODO(), "testbucket2", map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, "policy1", policy.ID)
+	require.Len(t, policy.Statement, 1)
+	assert.Equal(t, model.StringList{"s3:GetObject", "s3:ListBucket"}, policy.Statement[0].Action)
+	require.NoError(t, policy.Validate())
+
+	_, err = clientset.Buckets().GetPolicyDocument(context.TODO(), "unknownbucket", map[string]string{})
+	require.Error(t, err)
+}
+
+func testUpdatePolicyDocument(t *testing.T, clientset *rest.ClientSet) {
+	policy, err := model.NewBucketPolicyBuilder().
+		Allow("").
+		AnyPrincipal().
+		Actions("s3:GetObject").
+		Resources(model.ObjectsARN("testbucket2")).
+		Build()
+	require.NoError(t, err)
+
+	err = clientset.Buckets().UpdatePolicyDocument(context.TODO(), "testbucket2", *policy, map[string]string{})
+	require.NoError(t, err)
+
+	// Invalid policies are rejected before the request is sent.
+	err = clientset.Buckets().UpdatePolicyDocument(context.TODO(), "testbucket2", model.BucketPolicy{}, map[string]string{})
+	require.ErrorIs(t, err, model.ErrInvalidPolicy)
+}
+
 func testDeletePolicy(t *testing.T, clientset *rest.ClientSet) {
 	err := clientset.Buckets().DeletePolicy(context.TODO(), "testbucket1", map[string]string{})
 	require.Nil(t, err)
@@ -176,3 +214,118 @@ func testDeleteQuota(t *testing.T, clientset *rest.ClientSet) {
 	err := clientset.Buckets().DeleteQuota(context.TODO(), "testbucket1", "130820808912778549")
 	require.Nil(t, err)
 }
+
+func testApply(t *testing.T, clientset *rest.ClientSet) {
+	desired := model.Bucket{
+		Name:             "applybucket1",
+		ReplicationGroup: "urn:storageos:ReplicationGroupInfo:104b3728-fba1-41b3-8055-4592348f1d24:global",
+		Namespace:        "130820808912778549",
+		BlockSize:        10,
+	}
+
+	_, result, err := clientset.Buckets().Apply(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyCreated, result)
+
+	desired.Name = "applybucket2"
+	desired.BlockSize = 20
+
+	bucket, result, err := clientset.Buckets().Apply(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUpdated, result)
+	assert.Equal(t, int64(20), bucket.BlockSize)
+	assert.Equal(t, int64(5), bucket.NotificationSize)
+
+	desired.Name = "applybucket3"
+	desired.BlockSize = 10
+
+	_, result, err = clientset.Buckets().Apply(context.TODO(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUnchanged, result)
+
+	desired.Owner = "user2"
+
+	_, _, err = clientset.Buckets().Apply(context.TODO(), desired)
+	require.ErrorIs(t, err, model.ErrImmutableField)
+	assert.True(t, model.IsConflict(err))
+	assert.ErrorContains(t, err, "owner")
+}
+
+func testUpdateRetention(t *testing.T, clientset *rest.ClientSet) {
+	retention := model.BucketRetention{
+		DefaultRetention: 100,
+		Governor: model.MinMaxGovenor{
+			EnforceRetention:      true,
+			MinimumFixedRetention: 50,
+			MaximumFixedRetention: 200,
+		},
+	}
+
+	err := clientset.Buckets().UpdateRetention(context.TODO(), "retentionbucket1", "retention-ns1", retention)
+	require.NoError(t, err)
+
+	// The tenant of the second bucket is compliance-enabled.
+	retention.Governor.EnforceRetention = false
+
+	err = clientset.Buckets().UpdateRetention(context.TODO(), "retentionbucket2", "retention-ns2", retention)
+	require.ErrorIs(t, err, model.ErrComplianceViolation)
+
+	current, err := clientset.Buckets().GetRetention(context.TODO(), "retentionbucket2", "retention-ns2")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), current.DefaultRetention)
+	assert.True(t, current.Governor.EnforceRetention)
+	assert.Equal(t, int64(50), current.Governor.MinimumFixedRetention)
+}
+
+func testSearchMetadata(t *testing.T, clientset *rest.ClientSet) {
+	err := clientset.Buckets().DisableSearchMetadata(context.TODO(), "searchbucket1", "search-ns1")
+	require.NoError(t, err)
+
+	// Invalid configurations are refused before reaching the server.
+	_, err = clientset.Buckets().Create(context.TODO(), model.Bucket{
+		Name:      "searchbucket2",
+		Namespace: "search-ns1",
+		SearchMetadata: model.SearchMetadata{
+			MaxKeys:  1,
+			Enabled:  true,
+			Metadata: []model.Metadata{model.SystemMetadata("Size"), model.SystemMetadata("Owner")},
+		},
+	})
+	require.ErrorIs(t, err, model.ErrInvalidSearchMetadata)
+}
+
+func testACL(t *testing.T, clientset *rest.ClientSet) {
+	acl, err := clientset.Buckets().GetACL(context.TODO(), "aclbucket1", "acl-ns1")
+	require.NoError(t, err)
+	assert.Equal(t, "aclbucket1", acl.Bucket)
+	assert.Equal(t, model.ACL{
+		Owner:          "acl-ns1-admin",
+		UserACL:        []model.UserACL{{User: "alice", Permission: []model.ACLPermission{model.PermissionRead, model.PermissionWrite}}},
+		GroupACL:       []model.GroupACL{{Group: "public", Permission: []model.ACLPermission{model.PermissionRead}}},
+		CustomGroupACL: []model.CustomGroupACL{{CustomGroup: "auditors", Permission: []model.ACLPermission{model.PermissionReadACL}}},
+	}, acl.ACL)
+
+	acl.ACL.UserACL[0].Permission = []model.ACLPermission{model.PermissionFullControl}
+
+	err = clientset.Buckets().SetACL(context.TODO(), *acl)
+	require.NoError(t, err)
+
+	// Invalid ACLs are refused before reaching the server.
+	acl.ACL.GroupACL[0].Permission = []model.ACLPermission{"list"}
+
+	err = clientset.Buckets().SetACL(context.TODO(), *acl)
+	require.ErrorIs(t, err, model.ErrInvalidACL)
+}
+
+func testUpdateDefaultGroup(t *testing.T, clientset *rest.ClientSet) {
+	permissions := model.DefaultGroupPermissions{
+		File: model.GroupPermission{Read: true, Write: true},
+		Dir:  model.GroupPermission{Read: true, Execute: true},
+	}
+
+	err := clientset.Buckets().UpdateDefaultGroup(context.TODO(), "fsbucket1", "fs-ns1", "nfsusers", permissions)
+	require.NoError(t, err)
+
+	err = clientset.Buckets().UpdateDefaultGroup(context.TODO(), "fsbucket2", "fs-ns1", "nfsusers", permissions)
+	require.ErrorIs(t, err, model.ErrFSAccessDisabled)
+}
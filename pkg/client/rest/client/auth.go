@@ -19,6 +19,8 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +29,8 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dell/goobjectscale/pkg/client/model"
 )
@@ -71,7 +75,20 @@ type AuthService struct {
 	// ObjectScaleID is just that
 	ObjectScaleID string `json:"objectScaleID"`
 
+	// GatewayPool, if set, is used instead of Gateway to fail over between
+	// multiple auth endpoints.
+	GatewayPool *EndpointPool `json:"gatewayPool,omitempty"`
+
+	// TracerProvider is used to start a span for every login. If nil, a no-op
+	// provider is used.
+	TracerProvider trace.TracerProvider `json:"-"`
+
+	// Metrics receives login measurements. If nil, nothing is recorded.
+	Metrics Metrics `json:"-"`
+
 	token string
+
+	endpoint string
 }
 
 // IsAuthenticated returns true if the authenticated has been established.  This
@@ -82,7 +99,13 @@ func (auth *AuthService) IsAuthenticated() bool {
 }
 
 // Login obtains fresh authentication token(s) from the server.
-func (auth *AuthService) Login(ctx context.Context, ht *http.Client) error {
+func (auth *AuthService) Login(ctx context.Context, ht *http.Client) (err error) {
+	ctx, span := startLoginSpan(ctx, auth.TracerProvider, LoginMethodService)
+	defer func() {
+		metricsOrNoop(auth.Metrics).ObserveLogin(LoginMethodService, err)
+		endSpan(span, err)
+	}()
+
 	// urn:osc:{ObjectScaleID}:{ObjectStoreID}:service/{ServiceNameID}
 	serviceUrn := fmt.Sprintf("urn:osc:%s:%s:service/%s", auth.ObjectScaleID, "", auth.PodName)
 	// B64-{ObjectScaleID},{ObjectStoreID},{ServiceK8SNamespace},{ServiceNameID}
@@ -101,36 +124,48 @@ func (auth *AuthService) Login(ctx context.Context, ht *http.Client) error {
 
 	password := base64.StdEncoding.EncodeToString(h.Sum(nil))
 
-	u, err := url.Parse(auth.Gateway)
-	if err != nil {
-		return err
-	}
+	// Logins have no side effects, so they are safely sent again to another
+	// gateway.
+	resp, endpoint, err := tryEndpoints(ctx, auth.GatewayPool, auth.Gateway, true, func(gateway string) (*http.Response, error) {
+		u, err := url.Parse(gateway)
+		if err != nil {
+			return nil, err
+		}
 
-	u.Path = "/mgmt/serviceLogin"
+		u.Path = "/mgmt/serviceLogin"
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return err
-	}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
 
-	req.SetBasicAuth(userName, password)
+		req.SetBasicAuth(userName, password)
+		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
-	resp, err := ht.Do(req)
+		return ht.Do(req)
+	})
 	if err != nil {
-		return err
+		return &AuthError{Method: LoginMethodService, Reason: AuthReasonUnavailable, Err: err}
 	}
 
 	defer resp.Body.Close()
 
-	if err = HandleResponse(resp); err != nil {
+	span.SetAttributes(
+		AttrHTTPStatusCode.Int(resp.StatusCode),
+		AttrServerAddress.String(serverAddress(endpoint)),
+	)
+
+	if err = checkLoginResponse(LoginMethodService, resp); err != nil {
 		return err
 	}
 
-	auth.token = resp.Header.Get("X-SDS-AUTH-TOKEN")
+	auth.token = resp.Header.Get(HeaderAuthToken)
 	if auth.token == "" {
-		return fmt.Errorf("server error: login failed")
+		return &AuthError{Method: LoginMethodService, StatusCode: resp.StatusCode, Reason: AuthReasonBadResponse, Err: ErrNoToken}
 	}
 
+	auth.endpoint = endpoint
+
 	return nil
 }
 
@@ -139,6 +174,11 @@ func (auth *AuthService) Token() string {
 	return auth.token
 }
 
+// Endpoint returns the gateway that answered the last successful login.
+func (auth *AuthService) Endpoint() string {
+	return auth.endpoint
+}
+
 // AuthUser is an out-of-cluster or username+password based Authenticator.
 type AuthUser struct {
 	// Gateway is the auth endpoint
@@ -150,8 +190,21 @@ type AuthUser struct {
 	// Password used to authenticate management user
 	Password string `json:"password"`
 
+	// GatewayPool, if set, is used instead of Gateway to fail over between
+	// multiple auth endpoints.
+	GatewayPool *EndpointPool `json:"gatewayPool,omitempty"`
+
+	// TracerProvider is used to start a span for every login. If nil, a no-op
+	// provider is used.
+	TracerProvider trace.TracerProvider `json:"-"`
+
+	// Metrics receives login measurements. If nil, nothing is recorded.
+	Metrics Metrics `json:"-"`
+
 	token string
 
+	endpoint string
+
 	log logr.Logger
 }
 
@@ -167,10 +220,17 @@ func (auth *AuthUser) IsAuthenticated() bool {
 	return auth.token != ""
 }
 
-// Login obtains fresh authentication token(s) from the server.
+// Login obtains fresh authentication token(s) from the server. The RKE login
+// is tried first, falling back to the legacy login only if the server does
+// not support the RKE login. Rejected credentials and unavailable servers are
+// returned as they are, so that the same failure is not repeated.
 func (auth *AuthUser) Login(ctx context.Context, ht *http.Client) error {
 	err := auth.loginRKE(ctx, ht)
 	if err != nil {
+		if !shouldFallback(err) {
+			return err
+		}
+
 		auth.log.Error(err, "first authentication method failed")
 
 		return auth.loginLegacy(ctx, ht)
@@ -179,60 +239,115 @@ func (auth *AuthUser) Login(ctx context.Context, ht *http.Client) error {
 	return nil
 }
 
-// login is wrapper for common functionality between loginRKE and loginLegacy.
-func (auth *AuthUser) login(ctx context.Context, ht *http.Client,
-	path, method string, body io.Reader, mutators ...func(*http.Request),
-) (*http.Response, error) {
-	u, err := url.Parse(auth.Gateway)
-	if err != nil {
-		return nil, err
+// shouldFallback reports whether the legacy login should be tried after the
+// RKE login failed with err: the RKE login endpoint is missing, or it answered
+// with a content type other than JSON, e.g. the HTML page of an older cluster.
+func shouldFallback(err error) bool {
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		return false
 	}
 
-	u.Path = path
+	switch authErr.Reason {
+	case AuthReasonUnsupported:
+		return true
+	case AuthReasonBadResponse:
+		return errors.Is(err, ErrContentType)
+	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	return false
+}
+
+// login is wrapper for common functionality between loginRKE and loginLegacy.
+// It returns the response together with the gateway that sent it. Responses
+// with an error status are returned as AuthError.
+func (auth *AuthUser) login(ctx context.Context, ht *http.Client, loginMethod,
+	path, method string, body []byte, mutators ...func(*http.Request),
+) (*http.Response, string, error) {
+	// Logins have no side effects, so they are safely sent again to another
+	// gateway.
+	resp, endpoint, err := tryEndpoints(ctx, auth.GatewayPool, auth.Gateway, true, func(gateway string) (*http.Response, error) {
+		u, err := url.Parse(gateway)
+		if err != nil {
+			return nil, err
+		}
+
+		u.Path = path
+
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		// Mutators are used to modify request without duplication of code.
+		// Those can be used to inject headers, add basic authentication to request, etc.
+		for _, m := range mutators {
+			m(req)
+		}
+
+		propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		return ht.Do(req)
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", &AuthError{Method: loginMethod, Reason: AuthReasonUnavailable, Err: err}
 	}
 
-	// Mutators are used to modify request without duplication of code.
-	// Those can be used to inject headers, add basic authentication to request, etc.
-	for _, m := range mutators {
-		m(req)
-	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		AttrHTTPStatusCode.Int(resp.StatusCode),
+		AttrServerAddress.String(serverAddress(endpoint)),
+	)
 
-	resp, err := ht.Do(req)
-	if err != nil {
-		return nil, err
+	if err := checkLoginResponse(loginMethod, resp); err != nil {
+		resp.Body.Close()
+
+		return nil, "", err
 	}
 
-	return resp, nil
+	return resp, endpoint, nil
+}
+
+// loggedIn records the gateway that answered a successful login.
+func (auth *AuthUser) loggedIn(loginMethod, endpoint string) {
+	auth.endpoint = endpoint
+
+	auth.log.V(4).Info("Logged in.", "Method", loginMethod, "Gateway", endpoint) //nolint:gomnd
 }
 
 // loginLegacy is used to perform logging into the ObjectScale on legacy environment.
-func (auth *AuthUser) loginLegacy(ctx context.Context, ht *http.Client) error {
+func (auth *AuthUser) loginLegacy(ctx context.Context, ht *http.Client) (err error) {
+	ctx, span := startLoginSpan(ctx, auth.TracerProvider, LoginMethodLegacy)
+	defer func() {
+		metricsOrNoop(auth.Metrics).ObserveLogin(LoginMethodLegacy, err)
+		endSpan(span, err)
+	}()
+
 	basicAuth := func(r *http.Request) { r.SetBasicAuth(auth.Username, auth.Password) }
 
-	resp, err := auth.login(ctx, ht, "/mgmt/login", http.MethodGet, nil, basicAuth)
+	resp, endpoint, err := auth.login(ctx, ht, LoginMethodLegacy, "/mgmt/login", http.MethodGet, nil, basicAuth)
 	if err != nil {
-		return fmt.Errorf("login failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if err = HandleResponse(resp); err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	auth.token = resp.Header.Get("X-SDS-AUTH-TOKEN")
+	auth.token = resp.Header.Get(HeaderAuthToken)
 	if auth.token == "" {
-		return fmt.Errorf("server error: login failed")
+		return &AuthError{Method: LoginMethodLegacy, StatusCode: resp.StatusCode, Reason: AuthReasonBadResponse, Err: ErrNoToken}
 	}
 
+	auth.loggedIn(LoginMethodLegacy, endpoint)
+
 	return nil
 }
 
 // loginRKE is used to perform logging into the ObjectScale on RKE environment.
-func (auth *AuthUser) loginRKE(ctx context.Context, ht *http.Client) error {
+func (auth *AuthUser) loginRKE(ctx context.Context, ht *http.Client) (err error) {
+	ctx, span := startLoginSpan(ctx, auth.TracerProvider, LoginMethodRKE)
+	defer func() {
+		metricsOrNoop(auth.Metrics).ObserveLogin(LoginMethodRKE, err)
+		endSpan(span, err)
+	}()
+
 	b, err := json.Marshal(model.RKELoginRequest{
 		Username: auth.Username,
 		Password: auth.Password,
@@ -246,31 +361,37 @@ func (auth *AuthUser) loginRKE(ctx context.Context, ht *http.Client) error {
 		r.Header.Add("Accept", "application/json")
 	}
 
-	resp, err := auth.login(ctx, ht, "/mgmt/auth/login", http.MethodPost, bytes.NewReader(b), headers)
+	resp, endpoint, err := auth.login(ctx, ht, LoginMethodRKE, "/mgmt/auth/login", http.MethodPost, b, headers)
 	if err != nil {
-		return fmt.Errorf("login failed: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	if err = HandleResponse(resp); err != nil {
-		return err
+	badResponse := func(err error) error {
+		return &AuthError{Method: LoginMethodRKE, StatusCode: resp.StatusCode, Reason: AuthReasonBadResponse, Err: err}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading body failed: %w", err)
+		return badResponse(fmt.Errorf("reading body failed: %w", err))
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" && MediaType(contentType) != ContentTypeJSON {
+		return badResponse(&ContentTypeError{ContentType: contentType, StatusCode: resp.StatusCode, Snippet: snippet(body)})
 	}
 
 	rkeRes := &model.RKELoginResponse{}
 	if err := json.Unmarshal(body, rkeRes); err != nil {
-		return fmt.Errorf("unable to unmarshal login body: %w", err)
+		return badResponse(fmt.Errorf("unable to unmarshal login body: %w", err))
 	}
 
 	auth.token = rkeRes.AccessToken
 	if auth.token == "" {
-		return fmt.Errorf("server error: login failed")
+		return badResponse(ErrNoToken)
 	}
 
+	auth.loggedIn(LoginMethodRKE, endpoint)
+
 	return nil
 }
 
@@ -278,3 +399,76 @@ func (auth *AuthUser) loginRKE(ctx context.Context, ht *http.Client) error {
 func (auth *AuthUser) Token() string {
 	return auth.token
 }
+
+// Endpoint returns the gateway that answered the last successful login.
+func (auth *AuthUser) Endpoint() string {
+	return auth.endpoint
+}
+
+// maxErrorBodyLength is the maximum number of bytes of a login error response
+// that are decoded.
+const maxErrorBodyLength = 64 << 10
+
+// checkLoginResponse returns an AuthError if resp has an error status.
+func checkLoginResponse(loginMethod string, resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	return &AuthError{
+		Method:     loginMethod,
+		StatusCode: resp.StatusCode,
+		Reason:     authReason(resp.StatusCode),
+		Err:        decodeLoginError(resp),
+	}
+}
+
+// decodeLoginError decodes the body of a login error response according to
+// its content type, or its first character if the content type is not set.
+// JSON bodies may be in the management API or the RKE error format. It returns
+// nil if the body is empty.
+func decodeLoginError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	if err != nil {
+		return fmt.Errorf("response: %w", err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+
+	mediaType := MediaType(contentType)
+	if contentType == "" {
+		mediaType = sniff(body)
+	}
+
+	switch mediaType {
+	case ContentTypeJSON:
+		var rkeErr model.RKEErrorResponse
+		if err := json.Unmarshal(body, &rkeErr); err == nil && len(rkeErr.Messages) > 0 {
+			return rkeErr.AsError()
+		}
+
+		var apiErr model.Error
+		if err := json.Unmarshal(body, &apiErr); err != nil {
+			return fmt.Errorf("response: json: %w", err)
+		}
+
+		return apiErr
+	case ContentTypeXML:
+		var apiErr model.Error
+		if err := xml.Unmarshal(body, &apiErr); err != nil {
+			return fmt.Errorf("response: xml: %w", err)
+		}
+
+		return apiErr
+	}
+
+	return &ContentTypeError{
+		ContentType: contentType,
+		StatusCode:  resp.StatusCode,
+		Snippet:     snippet(body),
+	}
+}
@@ -14,10 +14,16 @@ package client_test
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/dell/goobjectscale/pkg/client/rest/client"
 )
 
@@ -58,3 +64,201 @@ func testUserLogin(t *testing.T, _ client.Authenticator) {
 	err = badAuth.Login(context.TODO(), NewTestHTTPClient())
 	require.Error(t, err)
 }
+
+// loginResponse is a canned response of a login endpoint.
+type loginResponse struct {
+	status      int
+	contentType string
+	token       string
+	body        string
+}
+
+// newLoginClient returns a test client answering login requests by path, and
+// the list of paths requested.
+func newLoginClient(responses map[string]loginResponse) (*http.Client, *[]string) {
+	var paths []string
+
+	return NewTestClient(func(req *http.Request) *http.Response {
+		paths = append(paths, req.URL.Path)
+
+		r, ok := responses[req.URL.Path]
+		if !ok {
+			r = loginResponse{status: http.StatusNotFound}
+		}
+
+		header := make(http.Header)
+		if r.contentType != "" {
+			header.Set("Content-Type", r.contentType)
+		}
+
+		if r.token != "" {
+			header.Set(client.HeaderAuthToken, r.token)
+		}
+
+		return &http.Response{
+			StatusCode: r.status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(r.body)),
+		}
+	}), &paths
+}
+
+func TestAuthError(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Rejected":       testAuthErrorRejected,
+		"Unsupported":    testAuthErrorUnsupported,
+		"Unavailable":    testAuthErrorUnavailable,
+		"BadResponse":    testAuthErrorBadResponse,
+		"ServiceXML":     testAuthErrorServiceXML,
+		"ServiceNoToken": testAuthErrorServiceNoToken,
+		"Transport":      testAuthErrorTransport,
+		"NoToken":        testAuthErrorNoToken,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testAuthErrorRejected(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+	user.Password = "wrong"
+
+	ht, paths := newLoginClient(map[string]loginResponse{
+		"/mgmt/auth/login": {
+			status:      http.StatusUnauthorized,
+			contentType: "application/json;charset=UTF-8",
+			body:        `{"http_status_code":401,"messages":[{"code":"4000","message":"Invalid credentials","severity":"ERROR"}]}`,
+		},
+	})
+
+	err := user.Login(context.TODO(), ht)
+
+	var authErr *client.AuthError
+
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, client.LoginMethodRKE, authErr.Method)
+	assert.Equal(t, http.StatusUnauthorized, authErr.StatusCode)
+	assert.Equal(t, client.AuthReasonRejected, authErr.Reason)
+	assert.Equal(t, "login (rke): 401 Unauthorized: credentials rejected: Invalid credentials", err.Error())
+	require.ErrorIs(t, err, model.Error{Code: 4000})
+	assert.Equal(t, []string{"/mgmt/auth/login"}, *paths)
+}
+
+func testAuthErrorUnsupported(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+
+	ht, paths := newLoginClient(map[string]loginResponse{
+		"/mgmt/login": {status: http.StatusOK, token: "TESTTOKEN"},
+	})
+
+	require.NoError(t, user.Login(context.TODO(), ht))
+	assert.Equal(t, "TESTTOKEN", user.Token())
+	assert.Equal(t, []string{"/mgmt/auth/login", "/mgmt/login"}, *paths)
+}
+
+func testAuthErrorUnavailable(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+
+	ht, paths := newLoginClient(map[string]loginResponse{
+		"/mgmt/auth/login": {
+			status:      http.StatusServiceUnavailable,
+			contentType: "text/html",
+			body:        "<html><body>Service Unavailable</body></html>",
+		},
+	})
+
+	err := user.Login(context.TODO(), ht)
+
+	var (
+		authErr *client.AuthError
+		ctErr   *client.ContentTypeError
+	)
+
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, client.AuthReasonUnavailable, authErr.Reason)
+	require.ErrorAs(t, err, &ctErr)
+	assert.Equal(t, "<html><body>Service Unavailable</body></html>", ctErr.Snippet)
+	assert.True(t, model.IsRetryable(err))
+	assert.Equal(t, []string{"/mgmt/auth/login"}, *paths)
+}
+
+func testAuthErrorBadResponse(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+
+	ht, paths := newLoginClient(map[string]loginResponse{
+		"/mgmt/auth/login": {status: http.StatusOK, contentType: "text/html", body: "<html></html>"},
+		"/mgmt/login":      {status: http.StatusOK},
+	})
+
+	err := user.Login(context.TODO(), ht)
+
+	var authErr *client.AuthError
+
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, client.LoginMethodLegacy, authErr.Method)
+	assert.Equal(t, client.AuthReasonBadResponse, authErr.Reason)
+	require.ErrorIs(t, err, client.ErrNoToken)
+	assert.Equal(t, []string{"/mgmt/auth/login", "/mgmt/login"}, *paths)
+}
+
+func testAuthErrorServiceXML(t *testing.T) {
+	service := FixtureServiceauth // shallow copy
+
+	ht, _ := newLoginClient(map[string]loginResponse{
+		"/mgmt/serviceLogin": {
+			status: http.StatusForbidden,
+			body:   `<?xml version="1.0" encoding="UTF-8"?><error><code>3001</code><description>Insufficient permissions</description></error>`,
+		},
+	})
+
+	err := service.Login(context.TODO(), ht)
+
+	var authErr *client.AuthError
+
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, client.LoginMethodService, authErr.Method)
+	assert.Equal(t, client.AuthReasonRejected, authErr.Reason)
+	assert.True(t, model.IsForbidden(err))
+	require.ErrorIs(t, err, model.Error{Code: 3001})
+}
+
+func testAuthErrorServiceNoToken(t *testing.T) {
+	service := FixtureServiceauth // shallow copy
+
+	ht, _ := newLoginClient(map[string]loginResponse{
+		"/mgmt/serviceLogin": {status: http.StatusOK},
+	})
+
+	err := service.Login(context.TODO(), ht)
+	require.ErrorIs(t, err, client.ErrNoToken)
+	assert.Equal(t, "login (service): 200 OK: bad response: no token in response", err.Error())
+}
+
+func testAuthErrorTransport(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+
+	ht := &http.Client{Transport: hostTransport{"testgateway": refuse}}
+
+	err := user.Login(context.TODO(), ht)
+
+	var authErr *client.AuthError
+
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, client.LoginMethodRKE, authErr.Method)
+	assert.Equal(t, client.AuthReasonUnavailable, authErr.Reason)
+	assert.Zero(t, authErr.StatusCode)
+	assert.False(t, errors.Is(err, client.ErrNoToken))
+}
+
+func testAuthErrorNoToken(t *testing.T) {
+	user := FixtureUserAuth // shallow copy
+
+	ht, paths := newLoginClient(map[string]loginResponse{
+		"/mgmt/auth/login": {status: http.StatusOK, contentType: "application/json", body: "{}"},
+	})
+
+	// The server supports the RKE login, so the legacy login is not tried.
+	err := user.Login(context.TODO(), ht)
+	require.ErrorIs(t, err, client.ErrNoToken)
+	assert.Equal(t, "login (rke): 200 OK: bad response: no token in response", err.Error())
+	assert.Equal(t, []string{"/mgmt/auth/login"}, *paths)
+}
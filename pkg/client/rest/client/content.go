@@ -0,0 +1,128 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxSnippetLength is the maximum number of bytes of an unexpected response
+// body kept in ContentTypeError.
+const MaxSnippetLength = 512
+
+// MediaType returns ContentTypeJSON or ContentTypeXML for the value of a
+// Content-Type header, ignoring its parameters. Both the application/* and
+// text/* forms and structured syntax suffixes (e.g. application/problem+json)
+// are recognized. An empty string is returned for all other media types.
+func MediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case mediaType == ContentTypeJSON, mediaType == "text/json", strings.HasSuffix(mediaType, "+json"):
+		return ContentTypeJSON
+	case mediaType == ContentTypeXML, mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		return ContentTypeXML
+	}
+
+	return ""
+}
+
+// accept returns the value of the Accept header, listing the preferred content
+// type first.
+func accept(preferred string) string {
+	switch preferred {
+	case ContentTypeJSON:
+		return ContentTypeJSON + ", " + ContentTypeXML + ";q=0.9"
+	default:
+		return ContentTypeXML + ", " + ContentTypeJSON + ";q=0.9"
+	}
+}
+
+var _ error = (*ContentTypeError)(nil) // interface guard
+
+// ContentTypeError is returned when the response body is neither XML nor JSON,
+// e.g. an HTML error page returned by a load balancer. It matches ErrContentType
+// with errors.Is.
+type ContentTypeError struct {
+	// ContentType is the Content-Type header of the response
+	ContentType string
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Snippet is the beginning of the response body, up to MaxSnippetLength bytes
+	Snippet string
+}
+
+// newContentTypeError builds a ContentTypeError, reading the body snippet from resp.
+func newContentTypeError(contentType string, resp *http.Response) *ContentTypeError {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, MaxSnippetLength))
+
+	return &ContentTypeError{
+		ContentType: contentType,
+		StatusCode:  resp.StatusCode,
+		Snippet:     snippet(b),
+	}
+}
+
+// snippet returns the beginning of body, up to MaxSnippetLength bytes.
+func snippet(body []byte) string {
+	if len(body) > MaxSnippetLength {
+		body = body[:MaxSnippetLength]
+	}
+
+	return strings.ToValidUTF8(string(body), "\uFFFD")
+}
+
+// sniff guesses the media type of a body sent without a Content-Type header.
+func sniff(body []byte) string {
+	body = bytes.TrimSpace(body)
+
+	switch {
+	case bytes.HasPrefix(body, []byte("{")), bytes.HasPrefix(body, []byte("[")):
+		return ContentTypeJSON
+	case bytes.HasPrefix(body, []byte("<")) && !bytes.HasPrefix(bytes.ToLower(body), []byte("<!doctype html")) &&
+		!bytes.HasPrefix(bytes.ToLower(body), []byte("<html")):
+		return ContentTypeXML
+	}
+
+	return ""
+}
+
+// Error implements the error interface.
+func (e *ContentTypeError) Error() string {
+	msg := fmt.Sprintf("response: %d %s: %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.ContentType, ErrContentType)
+	if e.Snippet != "" {
+		msg += fmt.Sprintf(": %q", e.Snippet)
+	}
+
+	return msg
+}
+
+// Unwrap returns ErrContentType.
+func (e *ContentTypeError) Unwrap() error {
+	return ErrContentType
+}
+
+// HTTPStatus returns the HTTP status code of the response.
+func (e *ContentTypeError) HTTPStatus() int {
+	return e.StatusCode
+}
@@ -0,0 +1,178 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestContent(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"MediaType":       testContentMediaType,
+		"Parameters":      testContentParameters,
+		"UnexpectedType":  testContentUnexpectedType,
+		"SnippetLimit":    testContentSnippetLimit,
+		"PreferredAccept": testContentPreferredAccept,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+// respondWith returns a test client answering every request with the status,
+// content type and body.
+func respondWith(status int, contentType, body string) *http.Client {
+	return NewTestClient(func(_ *http.Request) *http.Response {
+		header := make(http.Header)
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	})
+}
+
+func testContentMediaType(t *testing.T) {
+	for contentType, expected := range map[string]string{
+		"application/json":                client.ContentTypeJSON,
+		"application/json; charset=UTF-8": client.ContentTypeJSON,
+		"Application/JSON":                client.ContentTypeJSON,
+		"text/json":                       client.ContentTypeJSON,
+		"application/problem+json":        client.ContentTypeJSON,
+		"application/xml":                 client.ContentTypeXML,
+		"application/xml;charset=utf-8":   client.ContentTypeXML,
+		"text/xml; charset=ISO-8859-1":    client.ContentTypeXML,
+		"application/atom+xml":            client.ContentTypeXML,
+		"text/html":                       "",
+		"text/plain; charset=utf-8":       "",
+		"":                                "",
+		"not a media type;;":              "",
+	} {
+		assert.Equal(t, expected, client.MediaType(contentType), contentType)
+	}
+}
+
+func testContentParameters(t *testing.T) {
+	var into struct {
+		Name string `xml:"name" json:"name"`
+	}
+
+	c := client.Simple{
+		Endpoint:   "https://testserver",
+		HTTPClient: respondWith(http.StatusOK, "text/xml; charset=UTF-8", `<bucket><name>b1</name></bucket>`),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket/b1/info",
+		ContentType: client.ContentTypeJSON,
+	}, &into)
+	require.NoError(t, err)
+	assert.Equal(t, "b1", into.Name)
+
+	c.HTTPClient = respondWith(http.StatusNotFound, "application/json; charset=UTF-8",
+		`{"code":1004,"description":"Unable to find entity specified in URL"}`)
+
+	err = c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket/b1/info",
+		ContentType: client.ContentTypeXML,
+	}, &into)
+	require.ErrorIs(t, err, model.Error{Code: model.CodeParameterNotFound})
+}
+
+func testContentUnexpectedType(t *testing.T) {
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: respondWith(http.StatusBadGateway, "text/html",
+			`<html><body><h1>502 Bad Gateway</h1></body></html>`),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.ErrorIs(t, err, client.ErrContentType)
+
+	var ctErr *client.ContentTypeError
+
+	require.ErrorAs(t, err, &ctErr)
+	assert.Equal(t, "text/html", ctErr.ContentType)
+	assert.Equal(t, http.StatusBadGateway, ctErr.StatusCode)
+	assert.Equal(t, `<html><body><h1>502 Bad Gateway</h1></body></html>`, ctErr.Snippet)
+	assert.Contains(t, err.Error(), "502 Bad Gateway")
+	assert.True(t, model.IsRetryable(err))
+}
+
+func testContentSnippetLimit(t *testing.T) {
+	var into model.Error
+
+	c := client.Simple{
+		Endpoint:   "https://testserver",
+		HTTPClient: respondWith(http.StatusOK, "text/plain", strings.Repeat("x", 2*client.MaxSnippetLength)),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket",
+		ContentType: client.ContentTypeJSON,
+	}, &into)
+
+	var ctErr *client.ContentTypeError
+
+	require.ErrorAs(t, err, &ctErr)
+	assert.Len(t, ctErr.Snippet, client.MaxSnippetLength)
+}
+
+func testContentPreferredAccept(t *testing.T) {
+	var accept []string
+
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(req *http.Request) *http.Response {
+			accept = append(accept, req.Header.Get("Accept"))
+
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: http.NoBody}
+		}),
+	}
+
+	req := client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket",
+		ContentType: client.ContentTypeXML,
+	}
+
+	require.NoError(t, c.MakeRemoteCall(context.TODO(), req, nil))
+
+	c.PreferredContentType = client.ContentTypeJSON
+	require.NoError(t, c.MakeRemoteCall(context.TODO(), req, nil))
+
+	assert.Equal(t, []string{
+		"application/xml, application/json;q=0.9",
+		"application/json, application/xml;q=0.9",
+	}, accept)
+}
@@ -16,7 +16,13 @@
 
 package client
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
 
 var (
 	// ErrAuthorization is returned when the client is unable to authenticate with the server.
@@ -24,4 +30,159 @@ var (
 
 	// ErrContentType is returned when the client or server responds with an unknown content type header.
 	ErrContentType = errors.New("content type")
+
+	// ErrNoToken is returned when a login response does not contain the authentication token.
+	ErrNoToken = errors.New("no token in response")
+
+	// ErrNoEndpoints is returned when an endpoint pool does not contain any endpoints.
+	ErrNoEndpoints = errors.New("no endpoints")
 )
+
+// RequestIDHeaders are the response headers checked, in order, for the ID
+// assigned to the request by the server or a gateway in front of it. The
+// recorded management API responses carry none of them, so the request ID is
+// only known when a deployment adds one of these headers.
+var RequestIDHeaders = []string{"X-Request-Id", "X-Amz-Request-Id"}
+
+var _ error = (*APIError)(nil) // interface guard
+
+// APIError is returned when the management API responds with an error status.
+// It wraps the error decoded from the response body, so it can be inspected
+// with errors.As and errors.Is, and the predicates of the model package.
+type APIError struct {
+	// Err is the error decoded from the response body
+	Err model.Error
+
+	// Method is the HTTP method of the request
+	Method string
+
+	// Path is the templated path of the request, e.g. /object/bucket/{bucket}/info
+	Path string
+
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// RequestID is the ID assigned to the request, read from the first of
+	// RequestIDHeaders present in the response, or empty
+	RequestID string
+}
+
+// newAPIError builds an APIError for the response to r.
+func newAPIError(r Request, resp *http.Response, apiErr model.Error) *APIError {
+	return &APIError{
+		Err:        apiErr,
+		Method:     r.Method,
+		Path:       TemplatePath(r.Path),
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp.Header),
+	}
+}
+
+// requestID returns the value of the first of RequestIDHeaders set in header.
+func requestID(header http.Header) string {
+	for _, key := range RequestIDHeaders {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Err.Error())
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+
+	return msg
+}
+
+// Unwrap returns the error decoded from the response body.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatus returns the HTTP status code of the response.
+func (e *APIError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// AuthErrorReason classifies login failures.
+type AuthErrorReason string
+
+// Login failure reasons.
+const (
+	// AuthReasonRejected means the server rejected the credentials.
+	AuthReasonRejected AuthErrorReason = "credentials rejected"
+
+	// AuthReasonUnavailable means the server could not be reached or failed
+	// to process the request.
+	AuthReasonUnavailable AuthErrorReason = "server unavailable"
+
+	// AuthReasonUnsupported means the server does not support the login method.
+	AuthReasonUnsupported AuthErrorReason = "login method not supported"
+
+	// AuthReasonBadResponse means the server response could not be understood,
+	// e.g. it did not contain the token.
+	AuthReasonBadResponse AuthErrorReason = "bad response"
+)
+
+// authReason returns the reason of a login failure with the HTTP status.
+func authReason(status int) AuthErrorReason {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return AuthReasonRejected
+	case status == http.StatusNotFound, status == http.StatusMethodNotAllowed, status == http.StatusNotImplemented:
+		return AuthReasonUnsupported
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests, status >= http.StatusInternalServerError:
+		return AuthReasonUnavailable
+	default:
+		return AuthReasonBadResponse
+	}
+}
+
+var _ error = (*AuthError)(nil) // interface guard
+
+// AuthError is returned by authenticators when login fails.
+type AuthError struct {
+	// Method is the login method tried, e.g. LoginMethodRKE
+	Method string
+
+	// StatusCode is the HTTP status code of the response, or zero if no
+	// response was received
+	StatusCode int
+
+	// Reason is the class of the failure
+	Reason AuthErrorReason
+
+	// Err is the underlying error: the error decoded from the response body,
+	// the transport error, or nil
+	Err error
+}
+
+// Error implements the error interface.
+func (e *AuthError) Error() string {
+	msg := fmt.Sprintf("login (%s)", e.Method)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(": %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	msg += ": " + string(e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying error.
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatus returns the HTTP status code of the response.
+func (e *AuthError) HTTPStatus() int {
+	return e.StatusCode
+}
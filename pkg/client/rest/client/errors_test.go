@@ -0,0 +1,129 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestAPIError(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"NotFound":   testAPIErrorNotFound,
+		"StatusOnly": testAPIErrorStatusOnly,
+		"RequestID":  testAPIErrorRequestID,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testAPIErrorNotFound(t *testing.T) {
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(_ *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header:     make(http.Header),
+				Body: io.NopCloser(bytes.NewReader([]byte(
+					`{"code":1004,"description":"Unable to find entity specified in URL","details":"bucket1"}`,
+				))),
+			}
+		}),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket/bucket1/info",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.Error(t, err)
+
+	var apiErr *client.APIError
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, "/object/bucket/{bucket}/info", apiErr.Path)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, model.CodeParameterNotFound, apiErr.Err.Code)
+	assert.Equal(t,
+		"GET /object/bucket/{bucket}/info: 400 Bad Request: Unable to find entity specified in URL: bucket1",
+		err.Error())
+
+	var modelErr model.Error
+
+	require.ErrorAs(t, err, &modelErr)
+	require.ErrorIs(t, err, model.Error{Code: model.CodeParameterNotFound})
+	assert.True(t, model.IsNotFound(err))
+	assert.False(t, model.IsForbidden(err))
+}
+
+func testAPIErrorStatusOnly(t *testing.T) {
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(_ *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     make(http.Header),
+				Body:       http.NoBody,
+			}
+		}),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodDelete,
+		Path:        "/object/users/deactivate",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.Error(t, err)
+
+	assert.True(t, model.IsRetryable(err))
+	assert.False(t, model.IsNotFound(err))
+	assert.Equal(t, "DELETE /object/users/deactivate: 503 Service Unavailable: Unknown", err.Error())
+}
+
+func testAPIErrorRequestID(t *testing.T) {
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(_ *http.Request) *http.Response {
+			header := make(http.Header)
+			header.Set("X-Amz-Request-Id", "0a1b2c3d")
+
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Header:     header,
+				Body:       http.NoBody,
+			}
+		}),
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/object/bucket/bucket1/info",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+
+	var apiErr *client.APIError
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "0a1b2c3d", apiErr.RequestID)
+	assert.Equal(t, "GET /object/bucket/{bucket}/info: 500 Internal Server Error: Unknown (request id 0a1b2c3d)", err.Error())
+}
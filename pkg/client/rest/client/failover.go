@@ -0,0 +1,216 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultCooldown is the time an endpoint is considered unhealthy after a
+// failure, if EndpointPool.Cooldown is not set.
+const DefaultCooldown = 30 * time.Second
+
+// SelectionPolicy decides the order in which endpoints of a pool are tried.
+type SelectionPolicy string
+
+// Selection policies.
+const (
+	// PrimarySecondary always tries the endpoints in the configured order,
+	// i.e. the first healthy endpoint serves all the requests.
+	PrimarySecondary SelectionPolicy = "primary-secondary"
+
+	// RoundRobin spreads requests across all the healthy endpoints.
+	RoundRobin SelectionPolicy = "round-robin"
+)
+
+// EndpointPool is a list of equivalent endpoints with health tracking. An
+// endpoint is marked unhealthy on connection errors and on 502, 503 and 504
+// responses, and is tried again after Cooldown or once all the healthy
+// endpoints failed.
+type EndpointPool struct {
+	// Endpoints are the URLs of the endpoints
+	Endpoints []string `json:"endpoints"`
+
+	// Policy is the endpoint selection policy; PrimarySecondary by default
+	Policy SelectionPolicy `json:"policy,omitempty"`
+
+	// Cooldown is the time an endpoint is skipped after a failure
+	Cooldown time.Duration `json:"cooldown,omitempty"`
+
+	mu        sync.Mutex
+	next      int
+	unhealthy map[string]time.Time
+}
+
+// Candidates returns the endpoints in the order they should be tried: healthy
+// endpoints first, ordered according to the selection policy, followed by the
+// unhealthy ones.
+func (p *EndpointPool) Candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.Endpoints)
+	if n == 0 {
+		return nil
+	}
+
+	start := 0
+	if p.Policy == RoundRobin {
+		start = p.next % n
+		p.next = (p.next + 1) % n
+	}
+
+	var healthy, unhealthy []string
+
+	now := time.Now()
+
+	for i := 0; i < n; i++ {
+		endpoint := p.Endpoints[(start+i)%n]
+
+		if until, ok := p.unhealthy[endpoint]; ok && now.Before(until) {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// MarkFailure marks the endpoint as unhealthy for the cooldown period.
+func (p *EndpointPool) MarkFailure(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.unhealthy == nil {
+		p.unhealthy = make(map[string]time.Time)
+	}
+
+	cooldown := p.Cooldown
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+
+	p.unhealthy[endpoint] = time.Now().Add(cooldown)
+}
+
+// MarkSuccess marks the endpoint as healthy.
+func (p *EndpointPool) MarkSuccess(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.unhealthy, endpoint)
+}
+
+// IsHealthy reports whether the endpoint is currently considered healthy.
+func (p *EndpointPool) IsHealthy(endpoint string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until, ok := p.unhealthy[endpoint]
+
+	return !ok || !time.Now().Before(until)
+}
+
+// ShouldFailover reports whether the result of a request indicates that the
+// endpoint is unavailable and the next one should be tried: errors returned
+// by the HTTP client (but not cancellation of the request context) and 502,
+// 503 and 504 responses.
+func ShouldFailover(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		var urlErr *url.Error
+
+		return errors.As(err, &urlErr) && ctx.Err() == nil &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// IsIdempotent reports whether requests with the method can be sent again
+// after an unknown outcome without changing the result.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isConnectionFailure reports whether err is a failure to connect to the
+// endpoint, in which case the request was never sent.
+func isConnectionFailure(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// tryEndpoints calls fn with the candidate endpoints of the pool until the
+// result does not call for a failover, and returns that result together with
+// the endpoint that produced it. Requests that are not idempotent may have
+// been processed by an endpoint answering with a gateway error, so they fail
+// over only on connection failures. The result of the last candidate is
+// always returned. If pool is nil, fallback is the only candidate.
+func tryEndpoints(ctx context.Context, pool *EndpointPool, fallback string, idempotent bool,
+	fn func(endpoint string) (*http.Response, error),
+) (*http.Response, string, error) {
+	if pool == nil {
+		resp, err := fn(fallback)
+
+		return resp, fallback, err
+	}
+
+	candidates := pool.Candidates()
+	if len(candidates) == 0 {
+		return nil, "", ErrNoEndpoints
+	}
+
+	for i, endpoint := range candidates {
+		resp, err := fn(endpoint)
+
+		if !ShouldFailover(ctx, resp, err) {
+			pool.MarkSuccess(endpoint)
+
+			return resp, endpoint, err
+		}
+
+		pool.MarkFailure(endpoint)
+
+		if !idempotent && !isConnectionFailure(err) {
+			return resp, endpoint, err
+		}
+
+		if i == len(candidates)-1 {
+			return resp, endpoint, err
+		}
+
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+	}
+
+	// unreachable: the last candidate always returns
+	return nil, "", ErrNoEndpoints
+}
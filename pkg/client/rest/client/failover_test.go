@@ -0,0 +1,265 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+// hostTransport answers requests with the handler registered for the request host.
+type hostTransport map[string]func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t[req.URL.Host](req)
+}
+
+func respond(code int, header http.Header) func(*http.Request) (*http.Response, error) {
+	return func(_ *http.Request) (*http.Response, error) {
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		return &http.Response{StatusCode: code, Header: header, Body: http.NoBody}, nil
+	}
+}
+
+func refuse(_ *http.Request) (*http.Response, error) {
+	return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+}
+
+func TestFailover(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"PrimarySecondary": testFailoverPrimarySecondary,
+		"RoundRobin":       testFailoverRoundRobin,
+		"Cooldown":         testFailoverCooldown,
+		"ShouldFailover":   testFailoverShouldFailover,
+		"Simple":           testFailoverSimple,
+		"NonIdempotent":    testFailoverNonIdempotent,
+		"AllDown":          testFailoverAllDown,
+		"NoEndpoints":      testFailoverNoEndpoints,
+		"Gateway":          testFailoverGateway,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testFailoverPrimarySecondary(t *testing.T) {
+	pool := &client.EndpointPool{Endpoints: []string{"a", "b", "c"}}
+
+	assert.Equal(t, []string{"a", "b", "c"}, pool.Candidates())
+	assert.Equal(t, []string{"a", "b", "c"}, pool.Candidates())
+
+	pool.MarkFailure("a")
+	assert.False(t, pool.IsHealthy("a"))
+	assert.Equal(t, []string{"b", "c", "a"}, pool.Candidates())
+
+	pool.MarkSuccess("a")
+	assert.True(t, pool.IsHealthy("a"))
+	assert.Equal(t, []string{"a", "b", "c"}, pool.Candidates())
+}
+
+func testFailoverRoundRobin(t *testing.T) {
+	pool := &client.EndpointPool{Endpoints: []string{"a", "b", "c"}, Policy: client.RoundRobin}
+
+	assert.Equal(t, []string{"a", "b", "c"}, pool.Candidates())
+	assert.Equal(t, []string{"b", "c", "a"}, pool.Candidates())
+
+	pool.MarkFailure("a")
+	assert.Equal(t, []string{"c", "b", "a"}, pool.Candidates())
+}
+
+func testFailoverCooldown(t *testing.T) {
+	pool := &client.EndpointPool{Endpoints: []string{"a", "b"}, Cooldown: 10 * time.Millisecond}
+
+	pool.MarkFailure("a")
+	assert.Equal(t, []string{"b", "a"}, pool.Candidates())
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []string{"a", "b"}, pool.Candidates())
+}
+
+func testFailoverShouldFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	assert.True(t, client.ShouldFailover(context.TODO(), &http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+	assert.True(t, client.ShouldFailover(context.TODO(), &http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.True(t, client.ShouldFailover(context.TODO(), &http.Response{StatusCode: http.StatusGatewayTimeout}, nil))
+	assert.False(t, client.ShouldFailover(context.TODO(), &http.Response{StatusCode: http.StatusInternalServerError}, nil))
+	assert.False(t, client.ShouldFailover(context.TODO(), &http.Response{StatusCode: http.StatusOK}, nil))
+	assert.False(t, client.ShouldFailover(context.TODO(), nil, errors.New("not a transport error")))
+	assert.False(t, client.ShouldFailover(ctx, nil, context.Canceled))
+}
+
+func testFailoverSimple(t *testing.T) {
+	var served []string
+
+	record := func(fn func(*http.Request) (*http.Response, error)) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			served = append(served, req.URL.Host)
+
+			return fn(req)
+		}
+	}
+
+	pool := &client.EndpointPool{Endpoints: []string{"https://node1", "https://node2", "https://node3"}}
+	auth := FixtureServiceauth // shallow copy
+
+	c := client.Simple{
+		EndpointPool:  pool,
+		Authenticator: &auth,
+		HTTPClient: &http.Client{Transport: hostTransport{
+			"testgateway": respond(http.StatusOK, http.Header{"X-Sds-Auth-Token": []string{"TESTTOKEN"}}),
+			"node1":       record(refuse),
+			"node2":       record(respond(http.StatusServiceUnavailable, nil)),
+			"node3":       record(respond(http.StatusOK, nil)),
+		}},
+	}
+
+	req := client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}
+
+	require.NoError(t, c.MakeRemoteCall(context.TODO(), req, nil))
+	assert.Equal(t, []string{"node1", "node2", "node3"}, served)
+	assert.False(t, pool.IsHealthy("https://node1"))
+	assert.False(t, pool.IsHealthy("https://node2"))
+	assert.True(t, pool.IsHealthy("https://node3"))
+
+	// Healthy endpoint is tried first, with the same token.
+	served = nil
+
+	require.NoError(t, c.MakeRemoteCall(context.TODO(), req, nil))
+	assert.Equal(t, []string{"node3"}, served)
+	assert.Equal(t, "TESTTOKEN", auth.Token())
+}
+
+func testFailoverNonIdempotent(t *testing.T) {
+	var served []string
+
+	record := func(fn func(*http.Request) (*http.Response, error)) func(*http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			served = append(served, req.URL.Host)
+
+			return fn(req)
+		}
+	}
+
+	pool := &client.EndpointPool{Endpoints: []string{"https://node1", "https://node2", "https://node3"}}
+
+	c := client.Simple{
+		EndpointPool: pool,
+		HTTPClient: &http.Client{Transport: hostTransport{
+			"node1": record(refuse),
+			"node2": record(respond(http.StatusBadGateway, nil)),
+			"node3": record(respond(http.StatusOK, nil)),
+		}},
+	}
+
+	// The request never reached node1, but node2 may have processed it.
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodPost,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.Error(t, err)
+	assert.Equal(t, []string{"node1", "node2"}, served)
+	assert.False(t, pool.IsHealthy("https://node1"))
+	assert.False(t, pool.IsHealthy("https://node2"))
+
+	assert.True(t, client.IsIdempotent(http.MethodGet))
+	assert.True(t, client.IsIdempotent(http.MethodPut))
+	assert.True(t, client.IsIdempotent(http.MethodDelete))
+	assert.False(t, client.IsIdempotent(http.MethodPost))
+	assert.False(t, client.IsIdempotent(http.MethodPatch))
+}
+
+func testFailoverAllDown(t *testing.T) {
+	c := client.Simple{
+		EndpointPool: &client.EndpointPool{Endpoints: []string{"https://node1", "https://node2"}},
+		HTTPClient: &http.Client{Transport: hostTransport{
+			"node1": refuse,
+			"node2": respond(http.StatusServiceUnavailable, nil),
+		}},
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.Error(t, err)
+}
+
+func testFailoverNoEndpoints(t *testing.T) {
+	c := client.Simple{
+		EndpointPool: &client.EndpointPool{},
+		HTTPClient:   http.DefaultClient,
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.ErrorIs(t, err, client.ErrNoEndpoints)
+}
+
+func testFailoverGateway(t *testing.T) {
+	ht := &http.Client{Transport: hostTransport{
+		"gateway1": refuse,
+		"gateway2": respond(http.StatusOK, http.Header{"X-Sds-Auth-Token": []string{"TESTTOKEN"}}),
+	}}
+	pool := &client.EndpointPool{Endpoints: []string{"https://gateway1", "https://gateway2"}}
+
+	service := FixtureServiceauth // shallow copy
+	service.GatewayPool = pool
+	require.NoError(t, service.Login(context.TODO(), ht))
+	assert.Equal(t, "TESTTOKEN", service.Token())
+	assert.Equal(t, "https://gateway2", service.Endpoint())
+	assert.False(t, pool.IsHealthy("https://gateway1"))
+
+	// gateway2 supports the legacy login only.
+	ht = &http.Client{Transport: hostTransport{
+		"gateway1": refuse,
+		"gateway2": func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/mgmt/auth/login" {
+				return respond(http.StatusNotFound, nil)(req)
+			}
+
+			return respond(http.StatusOK, http.Header{"X-Sds-Auth-Token": []string{"TESTTOKEN"}})(req)
+		},
+	}}
+
+	user := client.AuthUser{
+		Username:    "testuser",
+		Password:    "testpassword",
+		GatewayPool: &client.EndpointPool{Endpoints: []string{"https://gateway1", "https://gateway2"}},
+	}
+	require.NoError(t, user.Login(context.TODO(), ht))
+	assert.Equal(t, "TESTTOKEN", user.Token())
+	assert.Equal(t, "https://gateway2", user.Endpoint())
+}
@@ -0,0 +1,72 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strconv"
+	"time"
+)
+
+// StatusTransportError is the status reported to Metrics when a request did
+// not receive any HTTP response.
+const StatusTransportError = "error"
+
+// Metrics receives client-side measurements from the Simple client and the
+// authenticators. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records a single HTTP request made for the operation
+	// (method and templated path, e.g. "GET /object/bucket/{bucket}/info"),
+	// the returned status code (or StatusTransportError) and its latency.
+	ObserveRequest(operation, status string, duration time.Duration)
+
+	// ObserveLogin records a login attempt made with the given login method;
+	// err is the result of the attempt.
+	ObserveLogin(method string, err error)
+
+	// ObserveDecodedBytes records the number of response body bytes decoded
+	// for the operation.
+	ObserveDecodedBytes(operation string, n int)
+}
+
+// NoopMetrics is a Metrics implementation that discards all measurements.
+type NoopMetrics struct{}
+
+var _ Metrics = NoopMetrics{} // interface guard
+
+// ObserveRequest implements the Metrics interface.
+func (NoopMetrics) ObserveRequest(string, string, time.Duration) {}
+
+// ObserveLogin implements the Metrics interface.
+func (NoopMetrics) ObserveLogin(string, error) {}
+
+// ObserveDecodedBytes implements the Metrics interface.
+func (NoopMetrics) ObserveDecodedBytes(string, int) {}
+
+// metricsOrNoop returns m, or NoopMetrics if m is nil.
+func metricsOrNoop(m Metrics) Metrics {
+	if m == nil {
+		return NoopMetrics{}
+	}
+
+	return m
+}
+
+// operation returns the operation name of the request used in metrics.
+func operation(r Request) string {
+	return r.Method + " " + TemplatePath(r.Path)
+}
+
+// statusLabel returns the status code as a string.
+func statusLabel(code int) string {
+	return strconv.Itoa(code)
+}
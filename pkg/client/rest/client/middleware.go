@@ -0,0 +1,84 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Headers set by the default middlewares.
+const (
+	HeaderAuthToken = "X-SDS-AUTH-TOKEN"
+	HeaderOverride  = "X-EMC-Override"
+)
+
+// Handler performs a single HTTP round trip.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler. It can modify the request before passing it
+// to next, inspect or replace the response returned by next, or
+// short-circuit the call without invoking next at all.
+type Middleware func(next Handler) Handler
+
+// Chain wraps h with the middlewares. The first middleware is the outermost
+// one, i.e. it sees the request first and the response last.
+func Chain(h Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	return h
+}
+
+// RequestMiddleware returns a Middleware calling fn on every request before
+// passing it on.
+func RequestMiddleware(fn func(req *http.Request)) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			fn(req)
+
+			return next(req)
+		}
+	}
+}
+
+// HeaderMiddleware returns a Middleware setting the header on every request.
+func HeaderMiddleware(key, value string) Middleware {
+	return RequestMiddleware(func(req *http.Request) {
+		req.Header.Set(key, value)
+	})
+}
+
+// AuthTokenMiddleware returns a Middleware setting the current token of the
+// Authenticator on every request.
+func AuthTokenMiddleware(auth Authenticator) Middleware {
+	return RequestMiddleware(func(req *http.Request) {
+		req.Header.Set(HeaderAuthToken, auth.Token())
+	})
+}
+
+// OverrideHeaderMiddleware returns a Middleware adding the X-EMC-Override
+// header to every request.
+func OverrideHeaderMiddleware() Middleware {
+	return HeaderMiddleware(HeaderOverride, "true")
+}
+
+// TraceContextMiddleware returns a Middleware propagating the W3C trace
+// context of the request context in the request headers.
+func TraceContextMiddleware() Middleware {
+	return RequestMiddleware(func(req *http.Request) {
+		propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+	})
+}
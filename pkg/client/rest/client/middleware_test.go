@@ -0,0 +1,196 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestMiddleware(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Chain":           testMiddlewareChain,
+		"DefaultHeaders":  testMiddlewareDefaultHeaders,
+		"CustomHeader":    testMiddlewareCustomHeader,
+		"ShortCircuit":    testMiddlewareShortCircuit,
+		"ResponseHandled": testMiddlewareResponseHandled,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+// recordingClient returns an HTTP client storing the last request sent and
+// answering with an empty 200 response.
+func recordingClient(last **http.Request) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		*last = req
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+		}
+	})
+}
+
+func testMiddlewareChain(t *testing.T) {
+	var order []string
+
+	trace := func(name string) client.Middleware {
+		return func(next client.Handler) client.Handler {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+":request")
+				resp, err := next(req)
+				order = append(order, name+":response")
+
+				return resp, err
+			}
+		}
+	}
+
+	h := client.Chain(func(_ *http.Request) (*http.Response, error) {
+		order = append(order, "handler")
+
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}, trace("first"), trace("second"))
+
+	resp, err := h(&http.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{
+		"first:request", "second:request", "handler", "second:response", "first:response",
+	}, order)
+}
+
+func testMiddlewareDefaultHeaders(t *testing.T) {
+	var last *http.Request
+
+	auth := FixtureServiceauth // shallow copy
+	require.NoError(t, auth.Login(context.TODO(), NewTestHTTPClient()))
+
+	c := client.Simple{
+		Endpoint:       "https://testserver",
+		Authenticator:  &auth,
+		HTTPClient:     recordingClient(&last),
+		OverrideHeader: true,
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "TESTTOKEN", last.Header.Get(client.HeaderAuthToken))
+	assert.Equal(t, "true", last.Header.Get(client.HeaderOverride))
+	assert.Len(t, c.DefaultMiddlewares(), 3)
+
+	c.Authenticator = nil
+	c.OverrideHeader = false
+
+	err = c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, last.Header.Get(client.HeaderAuthToken))
+	assert.Empty(t, last.Header.Get(client.HeaderOverride))
+	assert.Len(t, c.DefaultMiddlewares(), 1)
+}
+
+func testMiddlewareCustomHeader(t *testing.T) {
+	var last *http.Request
+
+	c := client.Simple{
+		Endpoint:   "https://testserver",
+		HTTPClient: recordingClient(&last),
+		Middlewares: []client.Middleware{
+			client.HeaderMiddleware("X-Request-ID", "request-1"),
+			client.RequestMiddleware(func(req *http.Request) {
+				req.Header.Set("X-Audit", req.Method+" "+req.URL.Path)
+			}),
+		},
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "request-1", last.Header.Get("X-Request-ID"))
+	assert.Equal(t, "GET /ok/json", last.Header.Get("X-Audit"))
+}
+
+func testMiddlewareShortCircuit(t *testing.T) {
+	errInjected := errors.New("injected fault")
+
+	var last *http.Request
+
+	c := client.Simple{
+		Endpoint:   "https://testserver",
+		HTTPClient: recordingClient(&last),
+		Middlewares: []client.Middleware{
+			func(_ client.Handler) client.Handler {
+				return func(_ *http.Request) (*http.Response, error) {
+					return nil, errInjected
+				}
+			},
+		},
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.ErrorIs(t, err, errInjected)
+	assert.Nil(t, last)
+}
+
+func testMiddlewareResponseHandled(t *testing.T) {
+	var last *http.Request
+
+	c := client.Simple{
+		Endpoint:   "https://testserver",
+		HTTPClient: recordingClient(&last),
+		Middlewares: []client.Middleware{
+			func(next client.Handler) client.Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					resp, err := next(req)
+					if err != nil {
+						return nil, err
+					}
+
+					resp.StatusCode = http.StatusUnauthorized
+
+					return resp, nil
+				}
+			},
+		},
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.ErrorIs(t, err, client.ErrAuthorization)
+}
@@ -0,0 +1,182 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the sensitive values in logs.
+const Redacted = "REDACTED"
+
+var (
+	// DefaultSensitiveHeaders are the headers always masked by Redactor.
+	DefaultSensitiveHeaders = []string{
+		"Authorization",
+		"Proxy-Authorization",
+		"Cookie",
+		"Set-Cookie",
+		HeaderAuthToken,
+		"X-Amz-Security-Token",
+	}
+
+	// DefaultSensitiveFields are the JSON and XML fields and the query
+	// parameters always masked by Redactor.
+	DefaultSensitiveFields = []string{
+		"password",
+		"secretkey",
+		"secret_key",
+		"secret_key_1",
+		"secret_key_2",
+		"access_token",
+		"refresh_token",
+		"sharedSecret",
+		"SecretAccessKey",
+		"SessionToken",
+	}
+)
+
+// Redactor masks secrets in headers, URLs and bodies before they are logged.
+// Matching of header and field names is case-insensitive. The zero value and
+// nil apply the default rules only. A Redactor must not be modified after its
+// first use.
+type Redactor struct {
+	// Headers are the names of masked headers, in addition to DefaultSensitiveHeaders
+	Headers []string
+
+	// Fields are the names of masked JSON and XML fields and query parameters,
+	// in addition to DefaultSensitiveFields
+	Fields []string
+
+	// Patterns are regular expressions matching additional masked text
+	Patterns []*regexp.Regexp
+
+	once    sync.Once
+	headers map[string]bool
+	fields  map[string]bool
+	json    *regexp.Regexp
+	xml     *regexp.Regexp
+}
+
+var defaultRedactor = &Redactor{}
+
+// init compiles the redaction rules.
+func (r *Redactor) init() {
+	r.headers = make(map[string]bool)
+	for _, h := range append(append([]string{}, DefaultSensitiveHeaders...), r.Headers...) {
+		r.headers[http.CanonicalHeaderKey(h)] = true
+	}
+
+	fields := append(append([]string{}, DefaultSensitiveFields...), r.Fields...)
+	quoted := make([]string, 0, len(fields))
+
+	r.fields = make(map[string]bool)
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+		quoted = append(quoted, regexp.QuoteMeta(f))
+	}
+
+	names := strings.Join(quoted, "|")
+
+	// string values, including values cut off by truncation of the body
+	r.json = regexp.MustCompile(`("(?i:` + names + `)"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|\\?$)`)
+	r.xml = regexp.MustCompile(`(<(?i:` + names + `)(?:\s[^>]*)?>)[^<]*`)
+}
+
+// rules returns r with the compiled rules, or the default Redactor if r is nil.
+func (r *Redactor) rules() *Redactor {
+	if r == nil {
+		r = defaultRedactor
+	}
+
+	r.once.Do(r.init)
+
+	return r
+}
+
+// Header returns a copy of h with the values of sensitive headers masked.
+func (r *Redactor) Header(h http.Header) http.Header {
+	r = r.rules()
+
+	redacted := h.Clone()
+	for k, v := range redacted {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			for i := range v {
+				v[i] = Redacted
+			}
+		}
+	}
+
+	return redacted
+}
+
+// URL returns u as a string, with the password and sensitive query parameters
+// masked.
+func (r *Redactor) URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	r = r.rules()
+
+	redacted := *u
+
+	if _, ok := u.User.Password(); ok {
+		redacted.User = url.UserPassword(u.User.Username(), Redacted)
+	}
+
+	q := u.Query()
+	masked := false
+
+	for k, v := range q {
+		if r.fields[strings.ToLower(k)] {
+			for i := range v {
+				v[i] = Redacted
+			}
+
+			masked = true
+		}
+	}
+
+	if masked {
+		redacted.RawQuery = q.Encode()
+	}
+
+	return r.Text(redacted.String())
+}
+
+// Body returns the body as text, with the values of sensitive JSON and XML
+// fields and the matches of Patterns masked.
+func (r *Redactor) Body(b []byte) string {
+	r = r.rules()
+
+	s := r.json.ReplaceAllString(string(b), `${1}"`+Redacted+`"`)
+	s = r.xml.ReplaceAllString(s, "${1}"+Redacted)
+
+	return r.Text(s)
+}
+
+// Text returns s with the matches of Patterns masked.
+func (r *Redactor) Text(s string) string {
+	r = r.rules()
+
+	for _, p := range r.Patterns {
+		s = p.ReplaceAllString(s, Redacted)
+	}
+
+	return s
+}
@@ -0,0 +1,162 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+)
+
+// routes is the list of known management API path templates. Segments wrapped
+// in curly braces match any single path segment.
+var routes = []string{
+	"/mgmt/login",
+	"/mgmt/serviceLogin",
+	"/mgmt/auth/login",
+	"/object/bucket",
+	"/object/bucket/{bucket}/info",
+	"/object/bucket/{bucket}/policy",
+	"/object/bucket/{bucket}/deactivate",
+	"/object/bucket/{bucket}/quota",
+	"/object/bucket/{bucket}/retention",
+	"/object/bucket/{bucket}/min-max-governor",
+	"/object/bucket/{bucket}/searchmetadata",
+	"/object/bucket/{bucket}/acl",
+	"/object/bucket/{bucket}/defaultgroup",
+	"/object/users",
+	"/object/users/{uid}/info",
+	"/object/user-secret-keys/{uid}",
+	"/object/user-secret-keys/{uid}/deactivate",
+	"/object/tenants",
+	"/object/tenants/tenant",
+	"/object/tenants/tenant/{tenant}",
+	"/object/tenants/tenant/{tenant}/delete",
+	"/object/tenants/tenant/{tenant}/quota",
+	"/object/mt/account/info",
+	"/object/mt/account/sample",
+	"/object/mt/account/{account}/bucket/info",
+	"/object/mt/account/{account}/bucket/sample",
+	"/object/mt/account/{account}/bucket/perf",
+	"/object/mt/account/{account}/replication/info",
+	"/object/mt/account/{account}/replication/sample",
+	"/object/mt/store/info",
+	"/object/mt/store/sample",
+	"/object/mt/store/replication",
+	"/vdc/alertpolicy",
+	"/vdc/alertpolicy/list",
+	"/vdc/alertpolicy/{policy}",
+	"/vdc/recovery-status/devices/{device}/levels/{level}",
+	"/replication/info",
+	"/replication/control/{objectscale}/{objectstore}",
+	"/replication/control/{objectscale}/{objectstore}/pause",
+	"/replication/control/{objectscale}/{objectstore}/suspend",
+	"/replication/control/{objectscale}/{objectstore}/resume",
+	"/replication/control/{objectscale}/{objectstore}/unthrottle",
+	"/replication/control/{objectscale}/{objectstore}/throttle",
+}
+
+// ParamSegment replaces the segments of unknown paths that are not static
+// segments of any known route.
+const ParamSegment = "{param}"
+
+// staticSegments are the static segments of the known routes.
+var staticSegments = func() map[string]bool {
+	static := make(map[string]bool)
+
+	for _, route := range routes {
+		for _, s := range splitPath(route) {
+			if !isParam(s) {
+				static[s] = true
+			}
+		}
+	}
+
+	return static
+}()
+
+// TemplatePath returns the route template matching the request path, with
+// resource names and identifiers replaced by placeholders, e.g.
+// "object/bucket/my-bucket/info" becomes "/object/bucket/{bucket}/info".
+// In paths that do not match any known template, the segments that are not
+// static segments of a known route are replaced by ParamSegment, so that
+// resource names never leak into span names, metric labels or errors.
+func TemplatePath(p string) string {
+	segments := splitPath(p)
+
+	var (
+		best      string
+		bestScore = -1
+	)
+
+	for _, route := range routes {
+		score, ok := matchRoute(splitPath(route), segments)
+		if ok && score > bestScore {
+			best, bestScore = route, score
+		}
+	}
+
+	if bestScore < 0 {
+		templated := make([]string, len(segments))
+
+		for i, s := range segments {
+			templated[i] = ParamSegment
+			if staticSegments[s] {
+				templated[i] = s
+			}
+		}
+
+		return "/" + strings.Join(templated, "/")
+	}
+
+	return best
+}
+
+// isParam reports whether the route segment is a placeholder.
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// matchRoute reports whether the segments match the route template. The
+// returned score is the number of static segments matched, so that static
+// routes (e.g. "/vdc/alertpolicy/list") win over parametrized ones.
+func matchRoute(route, segments []string) (int, bool) {
+	if len(route) != len(segments) {
+		return 0, false
+	}
+
+	score := 0
+
+	for i, r := range route {
+		switch {
+		case isParam(r):
+		case r == segments[i]:
+			score++
+		default:
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+// splitPath splits the path into non-empty segments.
+func splitPath(p string) []string {
+	var segments []string
+
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	return segments
+}
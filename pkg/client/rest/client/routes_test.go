@@ -0,0 +1,55 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestTemplatePath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{path: "object/bucket/my-bucket/info", expected: "/object/bucket/{bucket}/info"},
+		{path: "/object/bucket", expected: "/object/bucket"},
+		{path: "object/users/user1/info", expected: "/object/users/{uid}/info"},
+		{path: "/object/user-secret-keys/user1/deactivate", expected: "/object/user-secret-keys/{uid}/deactivate"},
+		{path: "object/tenants/tenant/ns1/", expected: "/object/tenants/tenant/{tenant}"},
+		{path: "/object/mt/account/acc1/bucket/info", expected: "/object/mt/account/{account}/bucket/info"},
+		{path: "vdc/alertpolicy/list", expected: "/vdc/alertpolicy/list"},
+		{path: "vdc/alertpolicy/policy1", expected: "/vdc/alertpolicy/{policy}"},
+		{path: "replication/control/os1/store1/pause", expected: "/replication/control/{objectscale}/{objectstore}/pause"},
+		{path: "object/bucket/b1/retention", expected: "/object/bucket/{bucket}/retention"},
+		{path: "object/bucket/b1/min-max-governor", expected: "/object/bucket/{bucket}/min-max-governor"},
+		{path: "object/bucket/b1/searchmetadata", expected: "/object/bucket/{bucket}/searchmetadata"},
+		{path: "object/bucket/b1/acl", expected: "/object/bucket/{bucket}/acl"},
+		{path: "object/bucket/b1/defaultgroup", expected: "/object/bucket/{bucket}/defaultgroup"},
+		{path: "object/tenants/tenant/", expected: "/object/tenants/tenant"},
+		{path: "/object/mt/store/replication", expected: "/object/mt/store/replication"},
+		{path: "unknown//path/", expected: "/{param}/{param}"},
+		{path: "/object/bucket/secret-bucket/new/info", expected: "/object/bucket/{param}/{param}/info"},
+		{path: "/object/users/deactivate", expected: "/object/users/deactivate"},
+		{path: "", expected: "/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, client.TemplatePath(tc.path))
+		})
+	}
+}
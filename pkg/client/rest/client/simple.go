@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/dell/goobjectscale/pkg/client/model"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var _ RemoteCaller = (*Simple)(nil) // interface guard
@@ -38,6 +40,10 @@ type Simple struct {
 	// Endpoint is the URL of the management API
 	Endpoint string `json:"endpoint"`
 
+	// EndpointPool, if set, is used instead of Endpoint to fail over between
+	// multiple management API endpoints.
+	EndpointPool *EndpointPool `json:"endpointPool,omitempty"`
+
 	// Authenticator!=nil means Authenticator.Login will be called to
 	// obtain login credentials.
 	Authenticator Authenticator
@@ -47,6 +53,30 @@ type Simple struct {
 
 	HTTPClient *http.Client
 
+	// PreferredContentType, if set to ContentTypeJSON or ContentTypeXML, is
+	// the response content type requested from every endpoint. Otherwise the
+	// content type of the request is preferred.
+	PreferredContentType string `json:"preferredContentType,omitempty"`
+
+	// Throttler, if set, limits the rate and concurrency of requests.
+	Throttler *Throttler
+
+	// Middlewares wrap every request sent and response received. They are
+	// applied in order, after the default middlewares (see DefaultMiddlewares).
+	Middlewares []Middleware
+
+	// Redactor masks secrets in logged requests and responses. If nil, the
+	// default rules are used.
+	Redactor *Redactor
+
+	// Metrics receives request and response measurements. If nil, nothing is
+	// recorded.
+	Metrics Metrics
+
+	// TracerProvider is used to start a span for every remote call. If nil,
+	// a no-op provider is used.
+	TracerProvider trace.TracerProvider
+
 	log logr.Logger
 }
 
@@ -57,40 +87,63 @@ func (s *Simple) SetLogger(log logr.Logger) {
 
 // MakeRemoteCall executes an API request against the client endpoint, returning
 // the object body of the response into a response object.
-func (s *Simple) MakeRemoteCall(ctx context.Context, r Request, into interface{}) error {
-	err := r.Validate(s.Endpoint)
+func (s *Simple) MakeRemoteCall(ctx context.Context, r Request, into interface{}) (err error) {
+	template := TemplatePath(r.Path)
+
+	ctx, span := tracer(s.TracerProvider).Start(ctx, operation(r),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrHTTPMethod.String(r.Method),
+			AttrURLTemplate.String(template),
+		),
+	)
+	defer func() { endSpan(span, err) }()
+
+	err = r.Validate(s.Endpoint)
 	if err != nil {
 		return fmt.Errorf("invalid request: %w", err)
 	}
 
 	// Do performs a single http request.
 	Do := func(ctx context.Context) error {
-		req, err := s.buildHTTPRequest(ctx, r)
-		if err != nil {
-			return err
-		}
-
-		s.log.V(8).Info("Request prepared.", //nolint:gomnd
-			"Header", req.Header,
-			"URL", req.URL,
+		var (
+			req     *http.Request
+			handler = Chain(s.send(r), s.middlewares()...)
 		)
 
-		resp, err := s.HTTPClient.Do(req)
+		resp, endpoint, err := tryEndpoints(ctx, s.EndpointPool, s.Endpoint, IsIdempotent(r.Method), func(endpoint string) (*http.Response, error) {
+			var err error
+
+			req, err = s.buildHTTPRequest(ctx, r, endpoint)
+			if err != nil {
+				return nil, err
+			}
+
+			return handler(req)
+		})
 		if err != nil {
 			return err
 		}
 
 		defer resp.Body.Close()
 
-		s.log.V(8).Info("Response obtained.", //nolint:gomnd
-			"ContentLength", resp.ContentLength,
-			"Header", req.Header,
-			"URL", req.URL,
-			"StatusCode", resp.StatusCode,
-			"Status", resp.Status,
-			"URL", resp.Header,
+		span.SetAttributes(
+			AttrHTTPStatusCode.Int(resp.StatusCode),
+			AttrServerAddress.String(req.URL.Host),
 		)
 
+		if log := s.log.V(8); log.Enabled() { //nolint:gomnd
+			log.Info("Response obtained.",
+				"Endpoint", endpoint,
+				"ContentLength", resp.ContentLength,
+				"Header", s.Redactor.Header(req.Header),
+				"URL", s.Redactor.URL(req.URL),
+				"StatusCode", resp.StatusCode,
+				"Status", resp.Status,
+				"ResponseHeader", s.Redactor.Header(resp.Header),
+			)
+		}
+
 		err = s.validateResponse(r, resp)
 		if err != nil {
 			return err
@@ -114,11 +167,13 @@ func (s *Simple) MakeRemoteCall(ctx context.Context, r Request, into interface{}
 
 	if !s.Authenticator.IsAuthenticated() {
 		if err := s.Authenticator.Login(ctx, s.HTTPClient); err != nil {
-			return fmt.Errorf("%w: login: %s", ErrAuthorization, err.Error())
+			return fmt.Errorf("%w: login: %w", ErrAuthorization, err)
 		}
 	}
 
 	for tries := 0; tries < AuthRetriesMax; tries++ {
+		span.SetAttributes(AttrRetryCount.Int(tries))
+
 		err := Do(ctx)
 
 		switch {
@@ -138,25 +193,76 @@ func (s *Simple) MakeRemoteCall(ctx context.Context, r Request, into interface{}
 	return fmt.Errorf("%w: exhausted authentication tries", ErrAuthorization)
 }
 
-func (s *Simple) buildHTTPRequest(ctx context.Context, r Request) (*http.Request, error) {
-	req, err := r.HTTPWithContext(ctx, s.Endpoint)
+func (s *Simple) buildHTTPRequest(ctx context.Context, r Request, endpoint string) (*http.Request, error) {
+	req, err := r.HTTPWithContext(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("simple client: %w", err)
 	}
 
-	req.Header.Add("Accept", r.ContentType)
-	req.Header.Add("Content-Type", r.ContentType)
-	req.Header.Add("Accept", "application/xml")
+	preferred := s.PreferredContentType
+	if preferred == "" {
+		preferred = r.ContentType
+	}
+
+	req.Header.Set("Accept", accept(preferred))
+	req.Header.Set("Content-Type", r.ContentType)
+
+	return req, nil
+}
+
+// DefaultMiddlewares returns the middlewares applied to every request before
+// Simple.Middlewares: throttling (if Throttler is set), propagation of the
+// trace context, the authentication token (if Authenticator is set) and the
+// X-EMC-Override header (if OverrideHeader is set).
+func (s *Simple) DefaultMiddlewares() []Middleware {
+	var middlewares []Middleware
+
+	if s.Throttler != nil {
+		middlewares = append(middlewares, s.Throttler.Middleware())
+	}
+
+	middlewares = append(middlewares, TraceContextMiddleware())
 
 	if s.Authenticator != nil {
-		req.Header.Add("X-SDS-AUTH-TOKEN", s.Authenticator.Token())
+		middlewares = append(middlewares, AuthTokenMiddleware(s.Authenticator))
 	}
 
 	if s.OverrideHeader {
-		req.Header.Add("X-EMC-Override", "true")
+		middlewares = append(middlewares, OverrideHeaderMiddleware())
 	}
 
-	return req, nil
+	return middlewares
+}
+
+// middlewares returns the default middlewares followed by the user-provided ones.
+func (s *Simple) middlewares() []Middleware {
+	return append(s.DefaultMiddlewares(), s.Middlewares...)
+}
+
+// send returns the innermost Handler of the middleware chain, which performs
+// the actual HTTP request.
+func (s *Simple) send(r Request) Handler {
+	return func(req *http.Request) (*http.Response, error) {
+		if log := s.log.V(8); log.Enabled() { //nolint:gomnd
+			log.Info("Request prepared.",
+				"Header", s.Redactor.Header(req.Header),
+				"URL", s.Redactor.URL(req.URL),
+			)
+		}
+
+		start := time.Now()
+
+		resp, err := s.HTTPClient.Do(req)
+		if err != nil {
+			metricsOrNoop(s.Metrics).ObserveRequest(operation(r), StatusTransportError, time.Since(start))
+
+			return nil, err
+		}
+
+		metricsOrNoop(s.Metrics).ObserveRequest(operation(r), statusLabel(resp.StatusCode), time.Since(start))
+
+		return resp, nil
+	}
 }
 
 func (s *Simple) validateResponse(r Request, resp *http.Response) error {
@@ -169,7 +275,7 @@ func (s *Simple) validateResponse(r Request, resp *http.Response) error {
 			return err
 		}
 
-		return ecsError
+		return newAPIError(r, resp, ecsError)
 	}
 
 	return nil
@@ -184,6 +290,11 @@ func (s *Simple) unmarshal(r Request, resp *http.Response, v interface{}) error
 		contentType = r.ContentType
 	}
 
+	mediaType := MediaType(contentType)
+	if mediaType == "" {
+		return newContentTypeError(contentType, resp)
+	}
+
 	// Reading all of resp.Body into memory is inefficient, and it's better to send
 	// it directly into decoders. However if body is empty the decoders will
 	// receive an EOF. They can also receive EOF for malformed responses.
@@ -207,9 +318,14 @@ func (s *Simple) unmarshal(r Request, resp *http.Response, v interface{}) error
 	}
 
 	// we want to attempt to log the body safely.
-	body = io.TeeReader(body, &LogWriter{log: s.log})
+	if s.log.V(10).Enabled() { //nolint:gomnd
+		lw := &LogWriter{log: s.log, redactor: s.Redactor}
+		defer lw.Flush()
 
-	switch contentType {
+		body = io.TeeReader(body, lw)
+	}
+
+	switch mediaType {
 	case ContentTypeJSON:
 		decoder := json.NewDecoder(body)
 		if err := HandleError(decoder.Decode(v)); err != nil {
@@ -220,9 +336,9 @@ func (s *Simple) unmarshal(r Request, resp *http.Response, v interface{}) error
 		if err := HandleError(decoder.Decode(v)); err != nil {
 			return fmt.Errorf("response: xml: %w", err)
 		}
-	default:
-		return fmt.Errorf("response: %s: %w", contentType, ErrContentType)
 	}
 
+	metricsOrNoop(s.Metrics).ObserveDecodedBytes(operation(r), cw.N)
+
 	return nil
 }
@@ -0,0 +1,246 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// OperationClass groups requests by their effect on the server.
+type OperationClass string
+
+// Operation classes.
+const (
+	// OperationRead are GET and HEAD requests.
+	OperationRead OperationClass = "read"
+	// OperationMutate are all the other requests.
+	OperationMutate OperationClass = "mutate"
+)
+
+// ClassOf returns the operation class of the HTTP method.
+func ClassOf(method string) OperationClass {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return OperationRead
+	default:
+		return OperationMutate
+	}
+}
+
+// Limits are token-bucket rate limit and concurrency settings.
+type Limits struct {
+	// RequestsPerSecond is the sustained request rate. Zero means unlimited.
+	RequestsPerSecond float64
+
+	// Burst is the maximum number of requests sent at once. Values lower than
+	// one are treated as one when RequestsPerSecond is set.
+	Burst int
+
+	// MaxInFlight is the maximum number of requests in progress at any time,
+	// including reading of the response body. Zero means unlimited.
+	MaxInFlight int
+}
+
+// EndpointLimits are limits applied to requests sent to a single endpoint.
+type EndpointLimits struct {
+	// Limits apply to all requests sent to the endpoint.
+	Limits
+
+	// Read, if set, additionally applies to read requests.
+	Read *Limits
+
+	// Mutate, if set, additionally applies to mutating requests.
+	Mutate *Limits
+}
+
+// Throttler limits the rate and concurrency of requests per endpoint and,
+// optionally, per operation class. Requests over the limits wait until they
+// can proceed or until the request context is done.
+type Throttler struct {
+	// Default applies to endpoints not listed in Endpoints.
+	Default EndpointLimits
+
+	// Endpoints are limits by endpoint host, e.g. "objectstore.example.com:4443".
+	Endpoints map[string]EndpointLimits
+
+	mu       sync.Mutex
+	limiters map[limiterKey]*limiter
+}
+
+type limiterKey struct {
+	host  string
+	class OperationClass
+}
+
+// limiter enforces a single Limits value.
+type limiter struct {
+	rate     *rate.Limiter
+	inFlight chan struct{}
+}
+
+func newLimiter(l Limits) *limiter {
+	lim := &limiter{}
+
+	if l.RequestsPerSecond > 0 {
+		lim.rate = rate.NewLimiter(rate.Limit(l.RequestsPerSecond), max(l.Burst, 1))
+	}
+
+	if l.MaxInFlight > 0 {
+		lim.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+
+	return lim
+}
+
+// acquire waits for the limiter and returns a function releasing the acquired
+// in-flight slot.
+func (lim *limiter) acquire(ctx context.Context) (func(), error) {
+	if lim.inFlight != nil {
+		select {
+		case lim.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if lim.inFlight != nil {
+			<-lim.inFlight
+		}
+	}
+
+	if lim.rate != nil {
+		if err := lim.rate.Wait(ctx); err != nil {
+			release()
+
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// limitsFor returns the limits configured for the endpoint host.
+func (t *Throttler) limitsFor(host string) EndpointLimits {
+	if l, ok := t.Endpoints[host]; ok {
+		return l
+	}
+
+	return t.Default
+}
+
+// limitersFor returns the limiters a request must pass through, lazily
+// creating them.
+func (t *Throttler) limitersFor(host string, class OperationClass) []*limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limiters == nil {
+		t.limiters = make(map[limiterKey]*limiter)
+	}
+
+	get := func(key limiterKey, l Limits) *limiter {
+		lim, ok := t.limiters[key]
+		if !ok {
+			lim = newLimiter(l)
+			t.limiters[key] = lim
+		}
+
+		return lim
+	}
+
+	limits := t.limitsFor(host)
+	limiters := []*limiter{get(limiterKey{host: host}, limits.Limits)}
+
+	classLimits := limits.Read
+	if class == OperationMutate {
+		classLimits = limits.Mutate
+	}
+
+	if classLimits != nil {
+		limiters = append(limiters, get(limiterKey{host: host, class: class}, *classLimits))
+	}
+
+	return limiters
+}
+
+// Wait blocks until a request of the method can be sent to the host, or until
+// ctx is done. On success the returned function must be called once the
+// request is finished.
+func (t *Throttler) Wait(ctx context.Context, host, method string) (func(), error) {
+	var releases []func()
+
+	releaseAll := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, lim := range t.limitersFor(host, ClassOf(method)) {
+		release, err := lim.acquire(ctx)
+		if err != nil {
+			releaseAll()
+
+			return nil, fmt.Errorf("throttle: %w", err)
+		}
+
+		releases = append(releases, release)
+	}
+
+	return releaseAll, nil
+}
+
+// Middleware returns a Middleware throttling requests. The in-flight slot is
+// held until the response body is closed.
+func (t *Throttler) Middleware() Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			release, err := t.Wait(req.Context(), req.URL.Host, req.Method)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next(req)
+			if err != nil || resp.Body == nil {
+				release()
+
+				return resp, err
+			}
+
+			resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+			return resp, nil
+		}
+	}
+}
+
+// releasingBody calls release once when closed.
+type releasingBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+// Close closes the underlying body and releases the throttler slot.
+func (b *releasingBody) Close() error {
+	defer b.once.Do(b.release)
+
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,210 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestThrottler(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"ClassOf":        testThrottlerClassOf,
+		"MaxInFlight":    testThrottlerMaxInFlight,
+		"RateLimit":      testThrottlerRateLimit,
+		"ContextDone":    testThrottlerContextDone,
+		"PerClass":       testThrottlerPerClass,
+		"PerEndpoint":    testThrottlerPerEndpoint,
+		"ReleaseOnClose": testThrottlerReleaseOnClose,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testThrottlerClassOf(t *testing.T) {
+	assert.Equal(t, client.OperationRead, client.ClassOf(http.MethodGet))
+	assert.Equal(t, client.OperationRead, client.ClassOf(http.MethodHead))
+	assert.Equal(t, client.OperationMutate, client.ClassOf(http.MethodPost))
+	assert.Equal(t, client.OperationMutate, client.ClassOf(http.MethodDelete))
+}
+
+func testThrottlerMaxInFlight(t *testing.T) {
+	var (
+		inFlight, peak int32
+		wg             sync.WaitGroup
+	)
+
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(_ *http.Request) *http.Response {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: http.NoBody}
+		}),
+		Throttler: &client.Throttler{
+			Default: client.EndpointLimits{Limits: client.Limits{MaxInFlight: 2}},
+		},
+	}
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := c.MakeRemoteCall(context.TODO(), client.Request{
+				Method:      http.MethodGet,
+				Path:        "/ok/json",
+				ContentType: client.ContentTypeJSON,
+			}, nil)
+			assert.NoError(t, err)
+		}()
+	}
+
+	wg.Wait()
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func testThrottlerRateLimit(t *testing.T) {
+	throttler := &client.Throttler{
+		Default: client.EndpointLimits{Limits: client.Limits{RequestsPerSecond: 50, Burst: 1}},
+	}
+
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		release, err := throttler.Wait(context.TODO(), "testserver", http.MethodGet)
+		require.NoError(t, err)
+		release()
+	}
+
+	// First request is served from the burst, the next four wait 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+}
+
+func testThrottlerContextDone(t *testing.T) {
+	throttler := &client.Throttler{
+		Default: client.EndpointLimits{Limits: client.Limits{MaxInFlight: 1}},
+	}
+
+	release, err := throttler.Wait(context.TODO(), "testserver", http.MethodGet)
+	require.NoError(t, err)
+
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = throttler.Wait(ctx, "testserver", http.MethodGet)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func testThrottlerPerClass(t *testing.T) {
+	throttler := &client.Throttler{
+		Default: client.EndpointLimits{
+			Mutate: &client.Limits{MaxInFlight: 1},
+		},
+	}
+
+	release, err := throttler.Wait(context.TODO(), "testserver", http.MethodPost)
+	require.NoError(t, err)
+
+	defer release()
+
+	// Reads are not limited by the mutate class limits.
+	readRelease, err := throttler.Wait(context.TODO(), "testserver", http.MethodGet)
+	require.NoError(t, err)
+	readRelease()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = throttler.Wait(ctx, "testserver", http.MethodPut)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func testThrottlerPerEndpoint(t *testing.T) {
+	throttler := &client.Throttler{
+		Endpoints: map[string]client.EndpointLimits{
+			"busy:4443": {Limits: client.Limits{MaxInFlight: 1}},
+		},
+	}
+
+	release, err := throttler.Wait(context.TODO(), "busy:4443", http.MethodGet)
+	require.NoError(t, err)
+
+	defer release()
+
+	otherRelease, err := throttler.Wait(context.TODO(), "idle:4443", http.MethodGet)
+	require.NoError(t, err)
+	otherRelease()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = throttler.Wait(ctx, "busy:4443", http.MethodGet)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func testThrottlerReleaseOnClose(t *testing.T) {
+	throttler := &client.Throttler{
+		Default: client.EndpointLimits{Limits: client.Limits{MaxInFlight: 1}},
+	}
+
+	h := client.Chain(func(_ *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte("body"))),
+		}, nil
+	}, throttler.Middleware())
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "https://testserver/", nil)
+	require.NoError(t, err)
+
+	resp, err := h(req)
+	require.NoError(t, err)
+
+	// The slot is held until the body is closed.
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = throttler.Wait(ctx, "testserver", http.MethodGet)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, resp.Body.Close())
+
+	release, err := throttler.Wait(context.TODO(), "testserver", http.MethodGet)
+	require.NoError(t, err)
+	release()
+}
@@ -0,0 +1,96 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// TracerName is the instrumentation name used for spans started by the client.
+const TracerName = "github.com/dell/goobjectscale/pkg/client/rest/client"
+
+// Attribute keys recorded on spans started by the client.
+const (
+	AttrHTTPMethod     = attribute.Key("http.request.method")
+	AttrHTTPStatusCode = attribute.Key("http.response.status_code")
+	AttrURLTemplate    = attribute.Key("url.template")
+	AttrServerAddress  = attribute.Key("server.address")
+	AttrErrorCode      = attribute.Key("objectscale.error.code")
+	AttrRetryCount     = attribute.Key("objectscale.retry_count")
+	AttrLoginMethod    = attribute.Key("objectscale.login.method")
+)
+
+// Login methods recorded in the AttrLoginMethod attribute.
+const (
+	LoginMethodRKE     = "rke"
+	LoginMethodLegacy  = "legacy"
+	LoginMethodService = "service"
+)
+
+// propagator injects W3C trace context into outgoing requests.
+var propagator = propagation.TraceContext{}
+
+// tracer returns a tracer from the provider, or a no-op tracer if the provider
+// is nil.
+func tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+
+	return tp.Tracer(TracerName)
+}
+
+// endSpan records the error, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		var apiErr model.Error
+		if errors.As(err, &apiErr) {
+			span.SetAttributes(AttrErrorCode.Int64(apiErr.Code))
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// serverAddress returns the host of the endpoint URL, as recorded in the
+// AttrServerAddress attribute.
+func serverAddress(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	return u.Host
+}
+
+// startLoginSpan starts a span for a login attempt performed with the given
+// method.
+func startLoginSpan(ctx context.Context, tp trace.TracerProvider, method string) (context.Context, trace.Span) {
+	return tracer(tp).Start(ctx, "login",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(AttrLoginMethod.String(method)),
+	)
+}
@@ -0,0 +1,175 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+func TestTracing(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"RemoteCall":  testTracingRemoteCall,
+		"APIError":    testTracingAPIError,
+		"UserLogin":   testTracingUserLogin,
+		"Propagation": testTracingPropagation,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func newRecordingProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func testTracingRemoteCall(t *testing.T) {
+	tp, recorder := newRecordingProvider()
+	auth := FixtureServiceauth // shallow copy
+	auth.TracerProvider = tp
+
+	c := client.Simple{
+		Endpoint:       "https://testserver",
+		Authenticator:  &auth,
+		HTTPClient:     NewTestHTTPClient(),
+		TracerProvider: tp,
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	login := spans[0]
+	assert.Equal(t, "login", login.Name())
+	assert.Equal(t, client.LoginMethodService, spanAttributes(login)[client.AttrLoginMethod].AsString())
+
+	call := spans[1]
+	attrs := spanAttributes(call)
+	// Unknown paths are templated, so that names never leak into span names.
+	assert.Equal(t, "GET /{param}/{param}", call.Name())
+	assert.Equal(t, http.MethodGet, attrs[client.AttrHTTPMethod].AsString())
+	assert.Equal(t, "/{param}/{param}", attrs[client.AttrURLTemplate].AsString())
+	assert.Equal(t, int64(http.StatusOK), attrs[client.AttrHTTPStatusCode].AsInt64())
+	assert.Equal(t, int64(0), attrs[client.AttrRetryCount].AsInt64())
+	assert.Equal(t, call.SpanContext().SpanID(), login.Parent().SpanID())
+}
+
+func testTracingAPIError(t *testing.T) {
+	tp, recorder := newRecordingProvider()
+
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(_ *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"code":1004,"description":"not found"}`))),
+				Header:     make(http.Header),
+			}
+		}),
+		TracerProvider: tp,
+	}
+
+	err := c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "object/bucket/secret-bucket/info",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	attrs := spanAttributes(spans[0])
+	assert.Equal(t, "GET /object/bucket/{bucket}/info", spans[0].Name())
+	assert.Equal(t, int64(http.StatusNotFound), attrs[client.AttrHTTPStatusCode].AsInt64())
+	assert.Equal(t, int64(1004), attrs[client.AttrErrorCode].AsInt64())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func testTracingUserLogin(t *testing.T) {
+	tp, recorder := newRecordingProvider()
+	auth := client.AuthUser{
+		Gateway:        "https://testgateway",
+		Username:       "testuser1",
+		Password:       "testpassword1",
+		TracerProvider: tp,
+	}
+
+	err := auth.Login(context.TODO(), NewTestHTTPClient())
+	require.Error(t, err)
+
+	// Rejected credentials do not fall back to the legacy login.
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, client.LoginMethodRKE, spanAttributes(spans[0])[client.AttrLoginMethod].AsString())
+	assert.Equal(t, int64(http.StatusUnauthorized), spanAttributes(spans[0])[client.AttrHTTPStatusCode].AsInt64())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func testTracingPropagation(t *testing.T) {
+	tp, _ := newRecordingProvider()
+
+	var traceparent string
+
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		HTTPClient: NewTestClient(func(req *http.Request) *http.Response {
+			traceparent = req.Header.Get("Traceparent")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+			}
+		}),
+		TracerProvider: tp,
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.TODO(), "parent")
+	defer parent.End()
+
+	err := c.MakeRemoteCall(ctx, client.Request{
+		Method:      http.MethodGet,
+		Path:        "/ok/json",
+		ContentType: client.ContentTypeJSON,
+	}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, traceparent, parent.SpanContext().TraceID().String())
+}
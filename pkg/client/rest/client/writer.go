@@ -13,7 +13,7 @@
 package client
 
 import (
-	"encoding/hex"
+	"bytes"
 	"io"
 
 	"github.com/go-logr/logr"
@@ -33,17 +33,37 @@ func (w *CountWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+// MaxLogBodyLength is the maximum number of bytes of a response body logged by
+// LogWriter.
+const MaxLogBodyLength = 4 << 10
+
+// LogWriter is an io.Writer that collects up to MaxLogBodyLength bytes written
+// to it, and logs them as redacted text on Flush.
 type LogWriter struct {
-	log logr.Logger
+	log      logr.Logger
+	redactor *Redactor
+	buf      bytes.Buffer
+	n        int
 }
 
-var _ io.Writer = (*LogWriter)(nil)
+var _ io.Writer = (*LogWriter)(nil) // interface guard
 
+// Write collects p and returns len(p) without writing anything.
 func (w *LogWriter) Write(p []byte) (int, error) {
-	w.log.V(10).WithCallDepth(1).Info("Writing.", //nolint:gomnd
-		"content", hex.Dump(p),
-		"length", len(p),
-	)
+	w.n += len(p)
+
+	if room := MaxLogBodyLength - w.buf.Len(); room > 0 {
+		w.buf.Write(p[:min(room, len(p))])
+	}
 
 	return len(p), nil
 }
+
+// Flush logs the collected bytes.
+func (w *LogWriter) Flush() {
+	w.log.V(10).WithCallDepth(1).Info("Response body.", //nolint:gomnd
+		"content", w.redactor.Body(w.buf.Bytes()),
+		"length", w.n,
+		"truncated", w.n > w.buf.Len(),
+	)
+}
@@ -42,11 +42,13 @@ var _ api.ClientSet = (*ClientSet)(nil)
 
 // NewClientSet returns a new client set based on the provided REST client parameters.
 func NewClientSet(c client.RemoteCaller) *ClientSet {
+	tenantsAPI := &tenants.Tenants{Client: c}
+
 	return &ClientSet{
 		client:                c,
-		buckets:               &buckets.Buckets{Client: c},
+		buckets:               &buckets.Buckets{Client: c, Tenants: tenantsAPI},
 		objectUser:            &objectuser.ObjectUser{Client: c},
-		tenants:               &tenants.Tenants{Client: c},
+		tenants:               tenantsAPI,
 		objmt:                 &objmt.Objmt{Client: c},
 		crr:                   &crr.CRR{Client: c},
 		alertPolicies:         &alertpolicies.AlertPolicies{Client: c},
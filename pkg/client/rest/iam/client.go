@@ -0,0 +1,636 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+// DefaultRegion is the region of the IAM client if none is configured.
+const DefaultRegion = "us-west-2"
+
+// Access key statuses.
+const (
+	AccessKeyActive   = iam.StatusTypeActive
+	AccessKeyInactive = iam.StatusTypeInactive
+)
+
+var (
+	// ErrMissingGateway is returned when no gateway is configured.
+	ErrMissingGateway = errors.New("missing gateway")
+
+	// ErrMissingAuthenticator is returned when no authenticator is configured.
+	ErrMissingAuthenticator = errors.New("missing authenticator")
+)
+
+// Config is the configuration of an IAM client.
+type Config struct {
+	// Gateway is the URL of the ObjectScale gateway; the IAM API is served
+	// under its /iam path
+	Gateway string
+
+	// AccountID is the ObjectScale account the requests apply to, unless
+	// another is set in the request context with WithAccountID
+	AccountID string
+
+	// Authenticator obtains the token sent with every request
+	Authenticator client.Authenticator
+
+	// HTTPClient is the client sending requests; http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	// Region is the region of the IAM client, DefaultRegion if empty
+	Region string
+}
+
+// Client is an ObjectScale IAM client. It covers the subset of the IAM API
+// supported by ObjectScale, follows pagination and translates errors with
+// TranslateError.
+type Client struct {
+	// IAM is the underlying IAM client, for operations not covered by Client
+	IAM iamiface.IAMAPI
+}
+
+// NewClient returns an IAM client authenticated with the token of the
+// authenticator, acting on the configured account.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Gateway == "" {
+		return nil, ErrMissingGateway
+	}
+
+	if cfg.Authenticator == nil {
+		return nil, ErrMissingAuthenticator
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:                      aws.String(strings.TrimSuffix(cfg.Gateway, "/") + "/iam"),
+		Region:                        aws.String(region),
+		Credentials:                   credentials.AnonymousCredentials,
+		CredentialsChainVerboseErrors: aws.Bool(true),
+		HTTPClient:                    httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	iamClient := iam.New(sess)
+
+	if err := InjectTokenToIAMClient(iamClient, cfg.Authenticator, *httpClient); err != nil {
+		return nil, err
+	}
+
+	if err := InjectAccountIDToIAMClient(iamClient, cfg.AccountID); err != nil {
+		return nil, err
+	}
+
+	return &Client{IAM: iamClient}, nil
+}
+
+// optional returns a pointer to s, or nil if s is empty.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return aws.String(s)
+}
+
+// decodeDocument decodes a URL-encoded policy document.
+func decodeDocument(document *string) (string, error) {
+	return url.QueryUnescape(aws.StringValue(document))
+}
+
+// CreateUser creates a user.
+func (c *Client) CreateUser(ctx context.Context, name, path string) (*iam.User, error) {
+	out, err := c.IAM.CreateUserWithContext(ctx, &iam.CreateUserInput{UserName: aws.String(name), Path: optional(path)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.User, nil
+}
+
+// GetUser returns a user.
+func (c *Client) GetUser(ctx context.Context, name string) (*iam.User, error) {
+	out, err := c.IAM.GetUserWithContext(ctx, &iam.GetUserInput{UserName: aws.String(name)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.User, nil
+}
+
+// DeleteUser deletes a user.
+func (c *Client) DeleteUser(ctx context.Context, name string) error {
+	_, err := c.IAM.DeleteUserWithContext(ctx, &iam.DeleteUserInput{UserName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListUsers returns the users whose path starts with pathPrefix.
+func (c *Client) ListUsers(ctx context.Context, pathPrefix string) ([]*iam.User, error) {
+	var users []*iam.User
+
+	err := c.IAM.ListUsersPagesWithContext(ctx, &iam.ListUsersInput{PathPrefix: optional(pathPrefix)},
+		func(page *iam.ListUsersOutput, _ bool) bool {
+			users = append(users, page.Users...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return users, nil
+}
+
+// CreateGroup creates a group.
+func (c *Client) CreateGroup(ctx context.Context, name, path string) (*iam.Group, error) {
+	out, err := c.IAM.CreateGroupWithContext(ctx, &iam.CreateGroupInput{GroupName: aws.String(name), Path: optional(path)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.Group, nil
+}
+
+// GetGroup returns a group and its users.
+func (c *Client) GetGroup(ctx context.Context, name string) (*iam.Group, []*iam.User, error) {
+	var (
+		group *iam.Group
+		users []*iam.User
+	)
+
+	err := c.IAM.GetGroupPagesWithContext(ctx, &iam.GetGroupInput{GroupName: aws.String(name)},
+		func(page *iam.GetGroupOutput, _ bool) bool {
+			group = page.Group
+			users = append(users, page.Users...)
+
+			return true
+		})
+	if err != nil {
+		return nil, nil, TranslateError(err)
+	}
+
+	return group, users, nil
+}
+
+// DeleteGroup deletes a group.
+func (c *Client) DeleteGroup(ctx context.Context, name string) error {
+	_, err := c.IAM.DeleteGroupWithContext(ctx, &iam.DeleteGroupInput{GroupName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListGroups returns the groups whose path starts with pathPrefix.
+func (c *Client) ListGroups(ctx context.Context, pathPrefix string) ([]*iam.Group, error) {
+	var groups []*iam.Group
+
+	err := c.IAM.ListGroupsPagesWithContext(ctx, &iam.ListGroupsInput{PathPrefix: optional(pathPrefix)},
+		func(page *iam.ListGroupsOutput, _ bool) bool {
+			groups = append(groups, page.Groups...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return groups, nil
+}
+
+// ListGroupsForUser returns the groups the user belongs to.
+func (c *Client) ListGroupsForUser(ctx context.Context, user string) ([]*iam.Group, error) {
+	var groups []*iam.Group
+
+	err := c.IAM.ListGroupsForUserPagesWithContext(ctx, &iam.ListGroupsForUserInput{UserName: aws.String(user)},
+		func(page *iam.ListGroupsForUserOutput, _ bool) bool {
+			groups = append(groups, page.Groups...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return groups, nil
+}
+
+// AddUserToGroup adds a user to a group.
+func (c *Client) AddUserToGroup(ctx context.Context, group, user string) error {
+	_, err := c.IAM.AddUserToGroupWithContext(ctx, &iam.AddUserToGroupInput{GroupName: aws.String(group), UserName: aws.String(user)})
+
+	return TranslateError(err)
+}
+
+// RemoveUserFromGroup removes a user from a group.
+func (c *Client) RemoveUserFromGroup(ctx context.Context, group, user string) error {
+	_, err := c.IAM.RemoveUserFromGroupWithContext(ctx, &iam.RemoveUserFromGroupInput{GroupName: aws.String(group), UserName: aws.String(user)})
+
+	return TranslateError(err)
+}
+
+// CreateRole creates a role that can be assumed by the principals allowed by
+// the trust policy document.
+func (c *Client) CreateRole(ctx context.Context, name, path, assumeRolePolicyDocument string) (*iam.Role, error) {
+	out, err := c.IAM.CreateRoleWithContext(ctx, &iam.CreateRoleInput{
+		RoleName:                 aws.String(name),
+		Path:                     optional(path),
+		AssumeRolePolicyDocument: aws.String(assumeRolePolicyDocument),
+	})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.Role, nil
+}
+
+// GetRole returns a role.
+func (c *Client) GetRole(ctx context.Context, name string) (*iam.Role, error) {
+	out, err := c.IAM.GetRoleWithContext(ctx, &iam.GetRoleInput{RoleName: aws.String(name)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.Role, nil
+}
+
+// DeleteRole deletes a role.
+func (c *Client) DeleteRole(ctx context.Context, name string) error {
+	_, err := c.IAM.DeleteRoleWithContext(ctx, &iam.DeleteRoleInput{RoleName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListRoles returns the roles whose path starts with pathPrefix.
+func (c *Client) ListRoles(ctx context.Context, pathPrefix string) ([]*iam.Role, error) {
+	var roles []*iam.Role
+
+	err := c.IAM.ListRolesPagesWithContext(ctx, &iam.ListRolesInput{PathPrefix: optional(pathPrefix)},
+		func(page *iam.ListRolesOutput, _ bool) bool {
+			roles = append(roles, page.Roles...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return roles, nil
+}
+
+// CreatePolicy creates a managed policy.
+func (c *Client) CreatePolicy(ctx context.Context, name, path, document string) (*iam.Policy, error) {
+	out, err := c.IAM.CreatePolicyWithContext(ctx, &iam.CreatePolicyInput{
+		PolicyName:     aws.String(name),
+		Path:           optional(path),
+		PolicyDocument: aws.String(document),
+	})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.Policy, nil
+}
+
+// GetPolicy returns a managed policy.
+func (c *Client) GetPolicy(ctx context.Context, arn string) (*iam.Policy, error) {
+	out, err := c.IAM.GetPolicyWithContext(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(arn)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.Policy, nil
+}
+
+// DeletePolicy deletes a managed policy.
+func (c *Client) DeletePolicy(ctx context.Context, arn string) error {
+	_, err := c.IAM.DeletePolicyWithContext(ctx, &iam.DeletePolicyInput{PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// ListPolicies returns the managed policies of the scope, one of the
+// iam.PolicyScopeType values; all policies if scope is empty.
+func (c *Client) ListPolicies(ctx context.Context, scope string) ([]*iam.Policy, error) {
+	var policies []*iam.Policy
+
+	err := c.IAM.ListPoliciesPagesWithContext(ctx, &iam.ListPoliciesInput{Scope: optional(scope)},
+		func(page *iam.ListPoliciesOutput, _ bool) bool {
+			policies = append(policies, page.Policies...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return policies, nil
+}
+
+// AttachUserPolicy attaches a managed policy to a user.
+func (c *Client) AttachUserPolicy(ctx context.Context, user, arn string) error {
+	_, err := c.IAM.AttachUserPolicyWithContext(ctx, &iam.AttachUserPolicyInput{UserName: aws.String(user), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// DetachUserPolicy detaches a managed policy from a user.
+func (c *Client) DetachUserPolicy(ctx context.Context, user, arn string) error {
+	_, err := c.IAM.DetachUserPolicyWithContext(ctx, &iam.DetachUserPolicyInput{UserName: aws.String(user), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// ListAttachedUserPolicies returns the managed policies attached to a user.
+func (c *Client) ListAttachedUserPolicies(ctx context.Context, user string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+
+	err := c.IAM.ListAttachedUserPoliciesPagesWithContext(ctx, &iam.ListAttachedUserPoliciesInput{UserName: aws.String(user)},
+		func(page *iam.ListAttachedUserPoliciesOutput, _ bool) bool {
+			policies = append(policies, page.AttachedPolicies...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return policies, nil
+}
+
+// AttachGroupPolicy attaches a managed policy to a group.
+func (c *Client) AttachGroupPolicy(ctx context.Context, group, arn string) error {
+	_, err := c.IAM.AttachGroupPolicyWithContext(ctx, &iam.AttachGroupPolicyInput{GroupName: aws.String(group), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// DetachGroupPolicy detaches a managed policy from a group.
+func (c *Client) DetachGroupPolicy(ctx context.Context, group, arn string) error {
+	_, err := c.IAM.DetachGroupPolicyWithContext(ctx, &iam.DetachGroupPolicyInput{GroupName: aws.String(group), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// ListAttachedGroupPolicies returns the managed policies attached to a group.
+func (c *Client) ListAttachedGroupPolicies(ctx context.Context, group string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+
+	err := c.IAM.ListAttachedGroupPoliciesPagesWithContext(ctx, &iam.ListAttachedGroupPoliciesInput{GroupName: aws.String(group)},
+		func(page *iam.ListAttachedGroupPoliciesOutput, _ bool) bool {
+			policies = append(policies, page.AttachedPolicies...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return policies, nil
+}
+
+// AttachRolePolicy attaches a managed policy to a role.
+func (c *Client) AttachRolePolicy(ctx context.Context, role, arn string) error {
+	_, err := c.IAM.AttachRolePolicyWithContext(ctx, &iam.AttachRolePolicyInput{RoleName: aws.String(role), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// DetachRolePolicy detaches a managed policy from a role.
+func (c *Client) DetachRolePolicy(ctx context.Context, role, arn string) error {
+	_, err := c.IAM.DetachRolePolicyWithContext(ctx, &iam.DetachRolePolicyInput{RoleName: aws.String(role), PolicyArn: aws.String(arn)})
+
+	return TranslateError(err)
+}
+
+// ListAttachedRolePolicies returns the managed policies attached to a role.
+func (c *Client) ListAttachedRolePolicies(ctx context.Context, role string) ([]*iam.AttachedPolicy, error) {
+	var policies []*iam.AttachedPolicy
+
+	err := c.IAM.ListAttachedRolePoliciesPagesWithContext(ctx, &iam.ListAttachedRolePoliciesInput{RoleName: aws.String(role)},
+		func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+			policies = append(policies, page.AttachedPolicies...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return policies, nil
+}
+
+// PutUserPolicy adds or replaces an inline policy of a user.
+func (c *Client) PutUserPolicy(ctx context.Context, user, name, document string) error {
+	_, err := c.IAM.PutUserPolicyWithContext(ctx, &iam.PutUserPolicyInput{
+		UserName:       aws.String(user),
+		PolicyName:     aws.String(name),
+		PolicyDocument: aws.String(document),
+	})
+
+	return TranslateError(err)
+}
+
+// GetUserPolicy returns the document of an inline policy of a user.
+func (c *Client) GetUserPolicy(ctx context.Context, user, name string) (string, error) {
+	out, err := c.IAM.GetUserPolicyWithContext(ctx, &iam.GetUserPolicyInput{UserName: aws.String(user), PolicyName: aws.String(name)})
+	if err != nil {
+		return "", TranslateError(err)
+	}
+
+	return decodeDocument(out.PolicyDocument)
+}
+
+// DeleteUserPolicy deletes an inline policy of a user.
+func (c *Client) DeleteUserPolicy(ctx context.Context, user, name string) error {
+	_, err := c.IAM.DeleteUserPolicyWithContext(ctx, &iam.DeleteUserPolicyInput{UserName: aws.String(user), PolicyName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListUserPolicies returns the names of the inline policies of a user.
+func (c *Client) ListUserPolicies(ctx context.Context, user string) ([]string, error) {
+	var names []string
+
+	err := c.IAM.ListUserPoliciesPagesWithContext(ctx, &iam.ListUserPoliciesInput{UserName: aws.String(user)},
+		func(page *iam.ListUserPoliciesOutput, _ bool) bool {
+			names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return names, nil
+}
+
+// PutGroupPolicy adds or replaces an inline policy of a group.
+func (c *Client) PutGroupPolicy(ctx context.Context, group, name, document string) error {
+	_, err := c.IAM.PutGroupPolicyWithContext(ctx, &iam.PutGroupPolicyInput{
+		GroupName:      aws.String(group),
+		PolicyName:     aws.String(name),
+		PolicyDocument: aws.String(document),
+	})
+
+	return TranslateError(err)
+}
+
+// GetGroupPolicy returns the document of an inline policy of a group.
+func (c *Client) GetGroupPolicy(ctx context.Context, group, name string) (string, error) {
+	out, err := c.IAM.GetGroupPolicyWithContext(ctx, &iam.GetGroupPolicyInput{GroupName: aws.String(group), PolicyName: aws.String(name)})
+	if err != nil {
+		return "", TranslateError(err)
+	}
+
+	return decodeDocument(out.PolicyDocument)
+}
+
+// DeleteGroupPolicy deletes an inline policy of a group.
+func (c *Client) DeleteGroupPolicy(ctx context.Context, group, name string) error {
+	_, err := c.IAM.DeleteGroupPolicyWithContext(ctx, &iam.DeleteGroupPolicyInput{GroupName: aws.String(group), PolicyName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListGroupPolicies returns the names of the inline policies of a group.
+func (c *Client) ListGroupPolicies(ctx context.Context, group string) ([]string, error) {
+	var names []string
+
+	err := c.IAM.ListGroupPoliciesPagesWithContext(ctx, &iam.ListGroupPoliciesInput{GroupName: aws.String(group)},
+		func(page *iam.ListGroupPoliciesOutput, _ bool) bool {
+			names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return names, nil
+}
+
+// PutRolePolicy adds or replaces an inline policy of a role.
+func (c *Client) PutRolePolicy(ctx context.Context, role, name, document string) error {
+	_, err := c.IAM.PutRolePolicyWithContext(ctx, &iam.PutRolePolicyInput{
+		RoleName:       aws.String(role),
+		PolicyName:     aws.String(name),
+		PolicyDocument: aws.String(document),
+	})
+
+	return TranslateError(err)
+}
+
+// GetRolePolicy returns the document of an inline policy of a role.
+func (c *Client) GetRolePolicy(ctx context.Context, role, name string) (string, error) {
+	out, err := c.IAM.GetRolePolicyWithContext(ctx, &iam.GetRolePolicyInput{RoleName: aws.String(role), PolicyName: aws.String(name)})
+	if err != nil {
+		return "", TranslateError(err)
+	}
+
+	return decodeDocument(out.PolicyDocument)
+}
+
+// DeleteRolePolicy deletes an inline policy of a role.
+func (c *Client) DeleteRolePolicy(ctx context.Context, role, name string) error {
+	_, err := c.IAM.DeleteRolePolicyWithContext(ctx, &iam.DeleteRolePolicyInput{RoleName: aws.String(role), PolicyName: aws.String(name)})
+
+	return TranslateError(err)
+}
+
+// ListRolePolicies returns the names of the inline policies of a role.
+func (c *Client) ListRolePolicies(ctx context.Context, role string) ([]string, error) {
+	var names []string
+
+	err := c.IAM.ListRolePoliciesPagesWithContext(ctx, &iam.ListRolePoliciesInput{RoleName: aws.String(role)},
+		func(page *iam.ListRolePoliciesOutput, _ bool) bool {
+			names = append(names, aws.StringValueSlice(page.PolicyNames)...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return names, nil
+}
+
+// CreateAccessKey creates an access key for a user. The secret of the key is
+// only returned at creation.
+func (c *Client) CreateAccessKey(ctx context.Context, user string) (*iam.AccessKey, error) {
+	out, err := c.IAM.CreateAccessKeyWithContext(ctx, &iam.CreateAccessKeyInput{UserName: aws.String(user)})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return out.AccessKey, nil
+}
+
+// ListAccessKeys returns the access keys of a user, without their secrets.
+func (c *Client) ListAccessKeys(ctx context.Context, user string) ([]*iam.AccessKeyMetadata, error) {
+	var keys []*iam.AccessKeyMetadata
+
+	err := c.IAM.ListAccessKeysPagesWithContext(ctx, &iam.ListAccessKeysInput{UserName: aws.String(user)},
+		func(page *iam.ListAccessKeysOutput, _ bool) bool {
+			keys = append(keys, page.AccessKeyMetadata...)
+
+			return true
+		})
+	if err != nil {
+		return nil, TranslateError(err)
+	}
+
+	return keys, nil
+}
+
+// UpdateAccessKey sets the status of an access key of a user to
+// AccessKeyActive or AccessKeyInactive.
+func (c *Client) UpdateAccessKey(ctx context.Context, user, accessKeyID, status string) error {
+	_, err := c.IAM.UpdateAccessKeyWithContext(ctx, &iam.UpdateAccessKeyInput{
+		UserName:    aws.String(user),
+		AccessKeyId: aws.String(accessKeyID),
+		Status:      aws.String(status),
+	})
+
+	return TranslateError(err)
+}
+
+// DeleteAccessKey deletes an access key of a user.
+func (c *Client) DeleteAccessKey(ctx context.Context, user, accessKeyID string) error {
+	_, err := c.IAM.DeleteAccessKeyWithContext(ctx, &iam.DeleteAccessKeyInput{UserName: aws.String(user), AccessKeyId: aws.String(accessKeyID)})
+
+	return TranslateError(err)
+}
@@ -0,0 +1,279 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iam_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	awsIAM "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	objscaleIAM "github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+// staticAuth is an authenticator holding a fixed token.
+type staticAuth struct{}
+
+func (staticAuth) IsAuthenticated() bool                     { return true }
+func (staticAuth) Login(context.Context, *http.Client) error { return nil }
+func (staticAuth) Token() string                             { return "TESTTOKEN" }
+
+// countingAuth is an authenticator issuing a new token on every login.
+type countingAuth struct {
+	logins int
+}
+
+func (a *countingAuth) IsAuthenticated() bool                     { return a.logins > 0 }
+func (a *countingAuth) Login(context.Context, *http.Client) error { a.logins++; return nil }
+func (a *countingAuth) Token() string                             { return fmt.Sprint("TOKEN", a.logins) }
+
+// iamResponses are the responses of the fake IAM API, by action and marker.
+var iamResponses = map[string]struct {
+	status int
+	body   string
+}{
+	"ListUsers": {http.StatusOK, `<ListUsersResponse><ListUsersResult><Users>` +
+		`<member><UserName>user1</UserName><Path>/</Path></member></Users>` +
+		`<IsTruncated>true</IsTruncated><Marker>page2</Marker></ListUsersResult></ListUsersResponse>`},
+	"ListUsers/page2": {http.StatusOK, `<ListUsersResponse><ListUsersResult><Users>` +
+		`<member><UserName>user2</UserName><Path>/</Path></member></Users>` +
+		`<IsTruncated>false</IsTruncated></ListUsersResult></ListUsersResponse>`},
+	"GetGroup": {http.StatusOK, `<GetGroupResponse><GetGroupResult><Group><GroupName>group1</GroupName></Group>` +
+		`<Users><member><UserName>user1</UserName></member></Users>` +
+		`<IsTruncated>true</IsTruncated><Marker>page2</Marker></GetGroupResult></GetGroupResponse>`},
+	"GetGroup/page2": {http.StatusOK, `<GetGroupResponse><GetGroupResult><Group><GroupName>group1</GroupName></Group>` +
+		`<Users><member><UserName>user2</UserName></member></Users>` +
+		`<IsTruncated>false</IsTruncated></GetGroupResult></GetGroupResponse>`},
+	"GetUserPolicy": {http.StatusOK, `<GetUserPolicyResponse><GetUserPolicyResult><UserName>user1</UserName>` +
+		`<PolicyName>inline</PolicyName><PolicyDocument>%7B%22Version%22%3A%222012-10-17%22%7D</PolicyDocument>` +
+		`</GetUserPolicyResult></GetUserPolicyResponse>`},
+	"CreateAccessKey": {http.StatusOK, `<CreateAccessKeyResponse><CreateAccessKeyResult><AccessKey>` +
+		`<UserName>user1</UserName><AccessKeyId>AKID</AccessKeyId><Status>Active</Status><SecretAccessKey>secret</SecretAccessKey>` +
+		`</AccessKey></CreateAccessKeyResult></CreateAccessKeyResponse>`},
+	"DeleteUser": {http.StatusNotFound, `<ErrorResponse><Error><Type>Sender</Type><Code>NoSuchEntity</Code>` +
+		`<Message>The user with name user3 cannot be found.</Message></Error><RequestId>1</RequestId></ErrorResponse>`},
+	"CreateGroup": {http.StatusConflict, `<ErrorResponse><Error><Type>Sender</Type><Code>EntityAlreadyExists</Code>` +
+		`<Message>Group with name group1 already exists.</Message></Error><RequestId>2</RequestId></ErrorResponse>`},
+	"ListRoles": {http.StatusBadRequest, `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code>` +
+		`<Message>Rate exceeded.</Message></Error><RequestId>3</RequestId></ErrorResponse>`},
+}
+
+// newFakeIAMClient returns an IAM client answering with iamResponses and
+// recording the last request.
+func newFakeIAMClient(t *testing.T, last *http.Request) *objscaleIAM.Client {
+	// A CA bundle from the environment cannot be loaded into the mock transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	httpClient := newTestClient(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		*last = *req
+
+		key := form.Get("Action")
+		if marker := form.Get("Marker"); marker != "" {
+			key += "/" + marker
+		}
+
+		response, ok := iamResponses[key]
+		if !ok {
+			response.status = http.StatusInternalServerError
+		}
+
+		return &http.Response{
+			StatusCode: response.status,
+			Header:     http.Header{"Content-Type": []string{"text/xml"}},
+			Body:       io.NopCloser(bytes.NewBufferString(response.body)),
+		}
+	})
+
+	c, err := objscaleIAM.NewClient(objscaleIAM.Config{
+		Gateway:       "https://testgateway",
+		AccountID:     "osai1",
+		Authenticator: staticAuth{},
+		HTTPClient:    httpClient,
+	})
+	require.NoError(t, err)
+
+	// Retries would replay throttled requests.
+	c.IAM.(*awsIAM.IAM).Retryer = awsclient.NoOpRetryer{}
+
+	return c
+}
+
+func TestClient(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"NewClient":       testNewClient,
+		"Pagination":      testClientPagination,
+		"InlinePolicy":    testClientInlinePolicy,
+		"AccessKey":       testClientAccessKey,
+		"TranslateErrors": testClientTranslateErrors,
+		"Relogin":         testClientRelogin,
+		"ContextAccount":  testClientContextAccount,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testNewClient(t *testing.T) {
+	_, err := objscaleIAM.NewClient(objscaleIAM.Config{Authenticator: staticAuth{}})
+	require.ErrorIs(t, err, objscaleIAM.ErrMissingGateway)
+
+	_, err = objscaleIAM.NewClient(objscaleIAM.Config{Gateway: "https://testgateway"})
+	require.ErrorIs(t, err, objscaleIAM.ErrMissingAuthenticator)
+}
+
+func testClientPagination(t *testing.T) {
+	var last http.Request
+
+	c := newFakeIAMClient(t, &last)
+
+	users, err := c.ListUsers(context.TODO(), "")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "user1", aws.StringValue(users[0].UserName))
+	assert.Equal(t, "user2", aws.StringValue(users[1].UserName))
+
+	assert.Equal(t, "https://testgateway/iam/", last.URL.String())
+	assert.Equal(t, "TESTTOKEN", last.Header.Get(objscaleIAM.SDSHeaderName))
+	assert.Equal(t, "osai1", last.Header.Get(objscaleIAM.AccountIDHeaderName))
+
+	group, members, err := c.GetGroup(context.TODO(), "group1")
+	require.NoError(t, err)
+	assert.Equal(t, "group1", aws.StringValue(group.GroupName))
+	assert.Len(t, members, 2)
+}
+
+func testClientInlinePolicy(t *testing.T) {
+	var last http.Request
+
+	c := newFakeIAMClient(t, &last)
+
+	document, err := c.GetUserPolicy(context.TODO(), "user1", "inline")
+	require.NoError(t, err)
+	assert.Equal(t, `{"Version":"2012-10-17"}`, document)
+}
+
+func testClientAccessKey(t *testing.T) {
+	var last http.Request
+
+	c := newFakeIAMClient(t, &last)
+
+	key, err := c.CreateAccessKey(context.TODO(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, "AKID", aws.StringValue(key.AccessKeyId))
+	assert.Equal(t, "secret", aws.StringValue(key.SecretAccessKey))
+	assert.Equal(t, objscaleIAM.AccessKeyActive, aws.StringValue(key.Status))
+}
+
+func testClientTranslateErrors(t *testing.T) {
+	var last http.Request
+
+	c := newFakeIAMClient(t, &last)
+
+	err := c.DeleteUser(context.TODO(), "user3")
+	require.Error(t, err)
+	assert.True(t, model.IsNotFound(err))
+	assert.ErrorIs(t, err, model.Error{Code: model.CodeResourceNotFound})
+
+	var awsErr awserr.Error
+	require.True(t, errors.As(err, &awsErr))
+	assert.Equal(t, "NoSuchEntity", awsErr.Code())
+
+	_, err = c.CreateGroup(context.TODO(), "group1", "")
+	assert.True(t, model.IsAlreadyExists(err))
+
+	_, err = c.ListRoles(context.TODO(), "")
+	assert.True(t, model.IsRetryable(err))
+}
+
+func testClientRelogin(t *testing.T) {
+	// A CA bundle from the environment cannot be loaded into the mock transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	var tokens [][]string
+
+	auth := &countingAuth{}
+	httpClient := newTestClient(func(req *http.Request) *http.Response {
+		tokens = append(tokens, req.Header.Values(objscaleIAM.SDSHeaderName))
+
+		if req.Header.Get(objscaleIAM.SDSHeaderName) != "TOKEN2" {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Header:     http.Header{"Content-Type": []string{"text/xml"}},
+				Body: io.NopCloser(bytes.NewBufferString(`<ErrorResponse><Error><Code>InvalidClientTokenId</Code>` +
+					`<Message>expired</Message></Error></ErrorResponse>`)),
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/xml"}},
+			Body:       io.NopCloser(bytes.NewBufferString(iamResponses["CreateAccessKey"].body)),
+		}
+	})
+
+	c, err := objscaleIAM.NewClient(objscaleIAM.Config{
+		Gateway:       "https://testgateway",
+		Authenticator: auth,
+		HTTPClient:    httpClient,
+	})
+	require.NoError(t, err)
+
+	_, err = c.CreateAccessKey(context.TODO(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, auth.logins)
+	assert.Equal(t, [][]string{{"TOKEN1"}, {"TOKEN2"}}, tokens)
+
+	// A request still rejected after a new login is not retried again.
+	auth.logins = 5
+	tokens = nil
+
+	_, err = c.CreateAccessKey(context.TODO(), "user1")
+	require.Error(t, err)
+	assert.Equal(t, 6, auth.logins)
+	assert.Equal(t, [][]string{{"TOKEN5"}, {"TOKEN6"}}, tokens)
+}
+
+func testClientContextAccount(t *testing.T) {
+	var last http.Request
+
+	c := newFakeIAMClient(t, &last)
+
+	_, err := c.CreateAccessKey(objscaleIAM.WithAccountID(context.TODO(), "osai2"), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"osai2"}, last.Header.Values(objscaleIAM.AccountIDHeaderName))
+
+	_, err = c.CreateAccessKey(context.TODO(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"osai1"}, last.Header.Values(objscaleIAM.AccountIDHeaderName))
+	assert.Equal(t, []string{"TESTTOKEN"}, last.Header.Values(objscaleIAM.SDSHeaderName))
+
+	accountID, ok := objscaleIAM.AccountIDFromContext(objscaleIAM.WithAccountID(context.TODO(), "osai3"))
+	assert.True(t, ok)
+	assert.Equal(t, "osai3", accountID)
+
+	_, ok = objscaleIAM.AccountIDFromContext(context.TODO())
+	assert.False(t, ok)
+}
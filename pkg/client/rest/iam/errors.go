@@ -0,0 +1,137 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iam
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// IAM error codes not declared by the IAM service package.
+const (
+	ErrCodeAccessDenied       = "AccessDenied"
+	ErrCodeThrottling         = "Throttling"
+	ErrCodeValidationError    = "ValidationError"
+	ErrCodeServiceUnavailable = "ServiceUnavailable"
+)
+
+// errorCodes maps IAM error codes to the management API error codes of the
+// same condition.
+var errorCodes = map[string]int64{
+	iam.ErrCodeNoSuchEntityException:   model.CodeResourceNotFound,
+	iam.ErrCodeLimitExceededException:  model.CodeExceedingLimit,
+	iam.ErrCodeServiceFailureException: model.CodeInternalException,
+}
+
+// errorStatuses maps IAM error codes to the HTTP status documented for them in
+// the IAM API reference, used if the error does not carry the response status.
+var errorStatuses = map[string]int{
+	iam.ErrCodeNoSuchEntityException:                  http.StatusNotFound,
+	iam.ErrCodeEntityAlreadyExistsException:           http.StatusConflict,
+	iam.ErrCodeDeleteConflictException:                http.StatusConflict,
+	iam.ErrCodeConcurrentModificationException:        http.StatusConflict,
+	iam.ErrCodeLimitExceededException:                 http.StatusConflict,
+	iam.ErrCodeInvalidInputException:                  http.StatusBadRequest,
+	iam.ErrCodeMalformedPolicyDocumentException:       http.StatusBadRequest,
+	iam.ErrCodePolicyNotAttachableException:           http.StatusBadRequest,
+	iam.ErrCodeUnmodifiableEntityException:            http.StatusBadRequest,
+	iam.ErrCodeEntityTemporarilyUnmodifiableException: http.StatusConflict,
+	iam.ErrCodeServiceFailureException:                http.StatusInternalServerError,
+	ErrCodeAccessDenied:                               http.StatusForbidden,
+	ErrCodeThrottling:                                 http.StatusBadRequest,
+	ErrCodeValidationError:                            http.StatusBadRequest,
+	ErrCodeServiceUnavailable:                         http.StatusServiceUnavailable,
+}
+
+// retryableCodes are the IAM error codes of requests that may succeed if retried.
+var retryableCodes = map[string]bool{
+	ErrCodeThrottling:         true,
+	ErrCodeServiceUnavailable: true,
+}
+
+// Error is an IAM API error translated into the error types of this library.
+// It matches both the management API error and the original IAM error with
+// errors.Is and errors.As.
+type Error struct {
+	// APIError is the management API error matching the IAM error code; its
+	// Code is zero if the management API has no code for the condition
+	APIError model.Error
+
+	// Status is the HTTP status of the response, or zero if none was received
+	Status int
+
+	// Err is the original error, wrapping an awserr.Error
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the management API error and the original IAM error, and
+// model.ErrAlreadyExists if the IAM entity already exists.
+func (e *Error) Unwrap() []error {
+	errs := []error{e.APIError, e.Err}
+
+	var awsErr awserr.Error
+	if errors.As(e.Err, &awsErr) && awsErr.Code() == iam.ErrCodeEntityAlreadyExistsException {
+		errs = append(errs, model.ErrAlreadyExists)
+	}
+
+	return errs
+}
+
+// HTTPStatus returns the HTTP status of the response.
+func (e *Error) HTTPStatus() int {
+	return e.Status
+}
+
+// TranslateError translates errors returned by the IAM API into errors
+// matching the management API error codes and HTTP statuses, so that the
+// predicates of the model package, such as model.IsNotFound, apply to them.
+// Other errors are returned unchanged.
+func TranslateError(err error) error {
+	var awsErr awserr.Error
+	if err == nil || !errors.As(err, &awsErr) {
+		return err
+	}
+
+	var translated *Error
+	if errors.As(err, &translated) {
+		return err
+	}
+
+	result := &Error{
+		APIError: model.Error{
+			Code:        errorCodes[awsErr.Code()],
+			Description: awsErr.Message(),
+			Details:     awsErr.Code(),
+			Retryable:   retryableCodes[awsErr.Code()],
+		},
+		Status: errorStatuses[awsErr.Code()],
+		Err:    err,
+	}
+
+	var failure awserr.RequestFailure
+	if errors.As(err, &failure) {
+		result.Status = failure.StatusCode()
+	}
+
+	return result
+}
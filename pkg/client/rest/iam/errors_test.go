@@ -0,0 +1,63 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iam_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	objscaleIAM "github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+func TestTranslateError(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err    error
+		code   int64
+		status int
+	}{
+		"no such entity":   {err: awserr.NewRequestFailure(awserr.New("NoSuchEntity", "missing", nil), http.StatusNotFound, "1"), code: model.CodeResourceNotFound, status: http.StatusNotFound},
+		"delete conflict":  {err: awserr.New("DeleteConflict", "attached", nil), status: http.StatusConflict},
+		"access denied":    {err: awserr.New("AccessDenied", "denied", nil), status: http.StatusForbidden},
+		"malformed policy": {err: awserr.New("MalformedPolicyDocument", "bad", nil), status: http.StatusBadRequest},
+		"unknown code":     {err: awserr.New("SomethingElse", "?", nil)},
+		"wrapped":          {err: fmt.Errorf("create: %w", awserr.New("LimitExceeded", "too many", nil)), code: model.CodeExceedingLimit, status: http.StatusConflict},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := objscaleIAM.TranslateError(tc.err)
+
+			var translated *objscaleIAM.Error
+			require.True(t, errors.As(err, &translated))
+			assert.Equal(t, tc.code, translated.APIError.Code)
+			assert.Equal(t, tc.status, translated.HTTPStatus())
+			assert.Equal(t, tc.err.Error(), err.Error())
+			assert.Same(t, err, objscaleIAM.TranslateError(err))
+		})
+	}
+
+	exists := objscaleIAM.TranslateError(awserr.New("EntityAlreadyExists", "exists", nil))
+	assert.True(t, model.IsAlreadyExists(exists))
+	assert.False(t, model.IsAlreadyExists(objscaleIAM.TranslateError(awserr.New("DeleteConflict", "attached", nil))))
+	assert.True(t, model.IsRetryable(objscaleIAM.TranslateError(awserr.New("Throttling", "slow down", nil))))
+
+	assert.NoError(t, objscaleIAM.TranslateError(nil))
+
+	plain := errors.New("plain")
+	assert.Same(t, plain, objscaleIAM.TranslateError(plain))
+}
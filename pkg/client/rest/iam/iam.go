@@ -31,12 +31,30 @@
 //			UserName: userName,
 //		})
 //	}
+//
+// NewClient builds the same client, with typed methods following pagination
+// and translating errors.
+//
+//	func ExampleNewClient(userName string) {
+//		objClient := client.AuthUser{Gateway: "https://testgateway", Username: "username", Password: "password"}
+//		iamClient, _ := NewClient(Config{
+//			Gateway:       "https://testgateway",
+//			AccountID:     "osaid185e2bf9e8ae35f",
+//			Authenticator: &objClient,
+//		})
+//		user, err := iamClient.CreateUser(context.TODO(), userName, "")
+//		if model.IsAlreadyExists(err) {
+//			user, err = iamClient.GetUser(context.TODO(), userName)
+//		}
+//	}
 package iam
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
@@ -52,16 +70,63 @@ const (
 	SDSHeaderName        = "X-Sds-Auth-Token"
 	AccountIDHandlerName = "X-Emc-Handler"
 	AccountIDHeaderName  = "X-Emc-Namespace"
+	SDSRetryHandlerName  = "X-Sds-Retry-Handler"
 )
 
+// accountIDKey is the context key of the account ID.
+type accountIDKey struct{}
+
+// reloginKey is the context key marking requests retried after a new login.
+type reloginKey struct{}
+
+// WithAccountID returns a copy of ctx carrying the ObjectScale account of the
+// IAM requests made with it. It takes precedence over the account ID injected
+// with InjectAccountIDToIAMClient, so that one IAM client can serve many
+// accounts.
+func WithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+// AccountIDFromContext returns the account ID carried by ctx, if any.
+func AccountIDFromContext(ctx context.Context) (string, bool) {
+	accountID, ok := ctx.Value(accountIDKey{}).(string)
+
+	return accountID, ok && accountID != ""
+}
+
 // InjectTokenToIAMClient configure IAM client to connect with Objectscale.
+// Requests rejected with 401 Unauthorized are retried once after a new login,
+// provided the retryer of the client allows a retry.
 func InjectTokenToIAMClient(clientIam iamiface.IAMAPI, clientObjectscale client.Authenticator, httpClient http.Client) error {
 	realIam, ok := clientIam.(*iam.IAM)
 	if !ok {
 		return errors.New("invalid iam client")
 	}
 
-	realIam.Handlers.Sign.RemoveByName(v4.SignRequestHandler.Name)
+	InjectTokenToHandlers(&realIam.Handlers, clientObjectscale, httpClient)
+
+	return nil
+}
+
+// InjectAccountIDToIAMClient configure IAM client to connect with Objectscale Account.
+// The account ID of the request context, set with WithAccountID, takes
+// precedence; accountID may be empty to only use the request context.
+func InjectAccountIDToIAMClient(clientIam iamiface.IAMAPI, accountID string) error {
+	realIam, ok := clientIam.(*iam.IAM)
+	if !ok {
+		return errors.New("invalid iam client")
+	}
+
+	InjectAccountIDToHandlers(&realIam.Handlers, accountID)
+
+	return nil
+}
+
+// InjectTokenToHandlers replaces SigV4 signing in the handlers of an
+// aws-sdk-go service client with the ObjectScale token, as
+// InjectTokenToIAMClient does for IAM clients.
+func InjectTokenToHandlers(handlers *request.Handlers, clientObjectscale client.Authenticator, httpClient http.Client) {
+	handlers.Sign.RemoveByName(v4.SignRequestHandler.Name)
 
 	handler := request.NamedHandler{
 		Name: SDSHandlerName,
@@ -74,37 +139,70 @@ func InjectTokenToIAMClient(clientIam iamiface.IAMAPI, clientObjectscale client.
 			}
 
 			token := clientObjectscale.Token()
-			r.HTTPRequest.Header.Add(SDSHeaderName, token)
+			r.HTTPRequest.Header.Set(SDSHeaderName, token)
 		},
 	}
 
-	swapped := realIam.Handlers.Sign.SwapNamed(handler)
+	swapped := handlers.Sign.SwapNamed(handler)
 	if !swapped {
-		realIam.Handlers.Sign.PushFrontNamed(handler)
+		handlers.Sign.PushFrontNamed(handler)
 	}
 
-	return nil
-}
+	retryHandler := request.NamedHandler{
+		Name: SDSRetryHandlerName,
+		Fn: func(r *request.Request) {
+			if r.HTTPResponse == nil || r.HTTPResponse.StatusCode != http.StatusUnauthorized {
+				return
+			}
 
-// InjectAccountIDToIAMClient configure IAM client to connect with Objectscale Account.
-func InjectAccountIDToIAMClient(clientIam iamiface.IAMAPI, accountID string) error {
-	realIam, ok := clientIam.(*iam.IAM)
-	if !ok {
-		return errors.New("invalid iam client")
+			if relogged, _ := r.Context().Value(reloginKey{}).(bool); relogged {
+				return
+			}
+
+			// The token expired or was revoked: log in again, and let the
+			// sign handler send the new token on retry.
+			if err := clientObjectscale.Login(r.Context(), &httpClient); err != nil {
+				r.Error = err
+
+				return
+			}
+
+			r.SetContext(context.WithValue(r.Context(), reloginKey{}, true))
+			r.Retryable = aws.Bool(true)
+		},
 	}
 
-	realIam.Handlers.Sign.RemoveByName(v4.SignRequestHandler.Name)
+	swapped = handlers.Retry.SwapNamed(retryHandler)
+	if !swapped {
+		handlers.Retry.PushBackNamed(retryHandler)
+	}
+}
+
+// InjectAccountIDToHandlers sets the ObjectScale account in the handlers of an
+// aws-sdk-go service client, as InjectAccountIDToIAMClient does for IAM
+// clients.
+func InjectAccountIDToHandlers(handlers *request.Handlers, accountID string) {
+	handlers.Sign.RemoveByName(v4.SignRequestHandler.Name)
 	handler := request.NamedHandler{
 		Name: AccountIDHandlerName,
 		Fn: func(r *request.Request) {
-			r.HTTPRequest.Header.Add(AccountIDHeaderName, accountID)
+			id := accountID
+			if fromContext, ok := AccountIDFromContext(r.Context()); ok {
+				id = fromContext
+			}
+
+			if id == "" {
+				r.HTTPRequest.Header.Del(AccountIDHeaderName)
+
+				return
+			}
+
+			r.HTTPRequest.Header.Set(AccountIDHeaderName, id)
 		},
 	}
 
-	swapped := realIam.Handlers.Sign.SwapNamed(handler)
+	swapped := handlers.Sign.SwapNamed(handler)
 	if !swapped {
-		realIam.Handlers.Sign.PushFrontNamed(handler)
+		handlers.Sign.PushFrontNamed(handler)
 	}
-
-	return nil
 }
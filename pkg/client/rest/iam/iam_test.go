@@ -142,6 +142,13 @@ func testInjectTokenToIAMClient(t *testing.T, iamClient *awsIAM.IAM) {
 	}
 	iamClient.Handlers.Sign.Run(r)
 	checkHeader(t, *r, objscaleIAM.SDSHeaderName, "TESTTOKEN")
+
+	// Handlers run again on retry replace the header.
+	iamClient.Handlers.Sign.Run(r)
+
+	if values := r.HTTPRequest.Header.Values(objscaleIAM.SDSHeaderName); len(values) != 1 {
+		t.Errorf("expected a single %v header, got %v", objscaleIAM.SDSHeaderName, values)
+	}
 }
 
 func testInjectAccountIDToIAMClient(t *testing.T, iamClient *awsIAM.IAM) {
@@ -170,6 +177,13 @@ func testInjectAccountIDToIAMClient(t *testing.T, iamClient *awsIAM.IAM) {
 	}
 	iamClient.Handlers.Sign.Run(r)
 	checkHeader(t, *r, objscaleIAM.AccountIDHeaderName, "yyy")
+
+	// Handlers run again on retry replace the headers.
+	iamClient.Handlers.Sign.Run(r)
+
+	if values := r.HTTPRequest.Header.Values(objscaleIAM.AccountIDHeaderName); len(values) != 1 {
+		t.Errorf("expected a single %v header, got %v", objscaleIAM.AccountIDHeaderName, values)
+	}
 }
 
 func checkHeader(t *testing.T, request request.Request, expectedHeader string, expectedHeaderValue string) {
@@ -189,6 +203,13 @@ func newTestHTTPClient() *http.Client {
 		header := make(http.Header)
 
 		switch req.URL.String() {
+		case "https://testgateway/mgmt/auth/login":
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       http.NoBody,
+				Header:     header,
+			}
+
 		case "https://testgateway/mgmt/login":
 			reqAuth := fmt.Sprint(req.Header["Authorization"])
 			defaultAuthCreds := "[Basic dGVzdHVzZXI6dGVzdHBhc3N3b3Jk]" //nolint:gosec
@@ -0,0 +1,140 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides a Prometheus implementation of client.Metrics.
+//
+// Example wiring the collector into the REST client.
+//
+//	func ExampleNewPrometheus() {
+//		m, _ := metrics.NewPrometheus(prometheus.DefaultRegisterer)
+//		user := &client.AuthUser{Gateway: "https://testgateway", Username: "username", Password: "password", Metrics: m}
+//		clientset := rest.NewClientSet(&client.Simple{
+//			Endpoint:      "https://testserver",
+//			Authenticator: user,
+//			HTTPClient:    http.DefaultClient,
+//			Metrics:       m,
+//		})
+//	}
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+)
+
+// Namespace and subsystem of all the exported metrics.
+const (
+	Namespace = "objectscale"
+	Subsystem = "client"
+)
+
+// Prometheus records client measurements as Prometheus metrics.
+type Prometheus struct {
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	logins        *prometheus.CounterVec
+	loginFailures *prometheus.CounterVec
+	decodedBytes  *prometheus.CounterVec
+}
+
+var (
+	_ client.Metrics       = (*Prometheus)(nil) // interface guard
+	_ prometheus.Collector = (*Prometheus)(nil) // interface guard
+)
+
+// NewPrometheus returns a new Prometheus metrics recorder registered in reg.
+// If reg is nil, the metrics are not registered.
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	p := &Prometheus{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "requests_total",
+			Help:      "Number of management API requests by operation and status code.",
+		}, []string{"operation", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of management API requests by operation and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "login_attempts_total",
+			Help:      "Number of login attempts by authenticator type.",
+		}, []string{"authenticator"}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "login_failures_total",
+			Help:      "Number of failed login attempts by authenticator type.",
+		}, []string{"authenticator"}),
+		decodedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "decoded_bytes_total",
+			Help:      "Number of response body bytes decoded by operation.",
+		}, []string{"operation"}),
+	}
+
+	if reg != nil {
+		if err := reg.Register(p); err != nil {
+			return nil, fmt.Errorf("register metrics: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// ObserveRequest implements the client.Metrics interface.
+func (p *Prometheus) ObserveRequest(operation, status string, duration time.Duration) {
+	p.requests.WithLabelValues(operation, status).Inc()
+	p.duration.WithLabelValues(operation, status).Observe(duration.Seconds())
+}
+
+// ObserveLogin implements the client.Metrics interface.
+func (p *Prometheus) ObserveLogin(method string, err error) {
+	p.logins.WithLabelValues(method).Inc()
+
+	if err != nil {
+		p.loginFailures.WithLabelValues(method).Inc()
+	}
+}
+
+// ObserveDecodedBytes implements the client.Metrics interface.
+func (p *Prometheus) ObserveDecodedBytes(operation string, n int) {
+	p.decodedBytes.WithLabelValues(operation).Add(float64(n))
+}
+
+// Describe implements the prometheus.Collector interface.
+func (p *Prometheus) Describe(ch chan<- *prometheus.Desc) {
+	p.requests.Describe(ch)
+	p.duration.Describe(ch)
+	p.logins.Describe(ch)
+	p.loginFailures.Describe(ch)
+	p.decodedBytes.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (p *Prometheus) Collect(ch chan<- prometheus.Metric) {
+	p.requests.Collect(ch)
+	p.duration.Collect(ch)
+	p.logins.Collect(ch)
+	p.loginFailures.Collect(ch)
+	p.decodedBytes.Collect(ch)
+}
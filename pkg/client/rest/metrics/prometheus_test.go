@@ -0,0 +1,137 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+	"github.com/dell/goobjectscale/pkg/client/rest/metrics"
+)
+
+// RoundTripFunc is a transport mock that makes a fake HTTP response locally.
+type RoundTripFunc func(req *http.Request) *http.Response
+
+// RoundTrip mocks an http request and returns an http response.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+func newTestHTTPClient() *http.Client {
+	return &http.Client{Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+		header := make(http.Header)
+
+		switch req.URL.Path {
+		case "/mgmt/auth/login":
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Header:     header,
+			}
+		case "/mgmt/login":
+			header.Set("X-Sds-Auth-Token", "TESTTOKEN")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(nil)),
+				Header:     header,
+			}
+		case "/object/bucket/testbucket/info":
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"name":"testbucket"}`))),
+				Header:     header,
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"code":1004}`))),
+			Header:     header,
+		}
+	})}
+}
+
+func TestPrometheus(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+
+	m, err := metrics.NewPrometheus(reg)
+	require.NoError(t, err)
+
+	_, err = metrics.NewPrometheus(reg)
+	require.Error(t, err)
+
+	c := client.Simple{
+		Endpoint: "https://testserver",
+		Authenticator: &client.AuthUser{
+			Gateway:  "https://testgateway",
+			Username: "testuser",
+			Password: "testpassword",
+			Metrics:  m,
+		},
+		HTTPClient: newTestHTTPClient(),
+		Metrics:    m,
+	}
+
+	bucket := &model.Bucket{}
+	err = c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "object/bucket/testbucket/info",
+		ContentType: client.ContentTypeJSON,
+	}, bucket)
+	require.NoError(t, err)
+
+	err = c.MakeRemoteCall(context.TODO(), client.Request{
+		Method:      http.MethodGet,
+		Path:        "object/bucket/missing/info",
+		ContentType: client.ContentTypeJSON,
+	}, bucket)
+	require.Error(t, err)
+
+	expected := `
+# HELP objectscale_client_decoded_bytes_total Number of response body bytes decoded by operation.
+# TYPE objectscale_client_decoded_bytes_total counter
+objectscale_client_decoded_bytes_total{operation="GET /object/bucket/{bucket}/info"} 34
+# HELP objectscale_client_login_attempts_total Number of login attempts by authenticator type.
+# TYPE objectscale_client_login_attempts_total counter
+objectscale_client_login_attempts_total{authenticator="legacy"} 1
+objectscale_client_login_attempts_total{authenticator="rke"} 1
+# HELP objectscale_client_login_failures_total Number of failed login attempts by authenticator type.
+# TYPE objectscale_client_login_failures_total counter
+objectscale_client_login_failures_total{authenticator="rke"} 1
+# HELP objectscale_client_requests_total Number of management API requests by operation and status code.
+# TYPE objectscale_client_requests_total counter
+objectscale_client_requests_total{operation="GET /object/bucket/{bucket}/info",status="200"} 1
+objectscale_client_requests_total{operation="GET /object/bucket/{bucket}/info",status="404"} 1
+`
+
+	err = testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"objectscale_client_decoded_bytes_total",
+		"objectscale_client_login_attempts_total",
+		"objectscale_client_login_failures_total",
+		"objectscale_client_requests_total",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "objectscale_client_request_duration_seconds"))
+}
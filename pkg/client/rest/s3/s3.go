@@ -0,0 +1,233 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package s3 builds S3 clients authenticated as ObjectScale object users.
+//
+// Example function creating an S3 client for a newly provisioned object user.
+//
+//	func ExampleNewS3Client(clientset *rest.ClientSet, mgmt *client.Simple, uid, namespace string) {
+//		secret, _ := clientset.ObjectUser().GetSecret(context.TODO(), uid, map[string]string{"namespace": namespace})
+//		s3Client, _ := s3.New(s3.Config{
+//			Endpoint:   "https://objectstore:9021",
+//			UserID:     uid,
+//			Namespace:  namespace,
+//			Secret:     *secret,
+//			HTTPClient: s3.ShareTLS(mgmt.HTTPClient),
+//		})
+//		_, _ = s3Client.ListBuckets(&awss3.ListBucketsInput{}) // github.com/aws/aws-sdk-go/service/s3
+//	}
+package s3
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+)
+
+// DefaultRegion is the region used to sign requests if none is configured.
+const DefaultRegion = "us-east-1"
+
+// Name of the handler and header added to S3 requests to select the namespace.
+const (
+	NamespaceHandlerName = "X-Emc-Namespace-Handler"
+	NamespaceHeaderName  = "X-Emc-Namespace"
+)
+
+// TimestampLayout is the layout of the key timestamps of object user secrets.
+const TimestampLayout = "2006-01-02 15:04:05.000"
+
+var (
+	// ErrNoValidKey is returned when an object user secret has no key that has
+	// not expired.
+	ErrNoValidKey = errors.New("no valid secret key")
+
+	// ErrMissingEndpoint is returned when no S3 endpoint is configured.
+	ErrMissingEndpoint = errors.New("missing S3 endpoint")
+
+	// ErrMissingUserID is returned when no object user is configured.
+	ErrMissingUserID = errors.New("missing object user ID")
+)
+
+// Config is the configuration of an S3 client.
+type Config struct {
+	// Endpoint is the URL of the S3 API of the object store
+	Endpoint string
+
+	// Region is the region used to sign requests, DefaultRegion if empty
+	Region string
+
+	// UserID is the object user, used as access key ID
+	UserID string
+
+	// Namespace, if set, is sent with every request to select the namespace
+	// of the buckets
+	Namespace string
+
+	// SecretKey, if set, is used instead of the key selected from Secret
+	SecretKey string
+
+	// Secret holds the secret keys of the object user
+	Secret model.ObjectUserSecret
+
+	// HTTPClient is the client sending requests; http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	// Now returns the current time, used to skip expired keys; time.Now if nil
+	Now func() time.Time
+
+	// Credentials, if set, are used instead of the keys of the object user,
+	// e.g. temporary credentials refreshed by an sts.Provider
+	Credentials *credentials.Credentials
+}
+
+// New returns an S3 client authenticated as the configured object user, using
+// path-style addressing.
+func New(cfg Config) (s3iface.S3API, error) {
+	if cfg.Endpoint == "" {
+		return nil, ErrMissingEndpoint
+	}
+
+	creds := cfg.Credentials
+	if creds == nil {
+		if cfg.UserID == "" {
+			return nil, ErrMissingUserID
+		}
+
+		secretKey := cfg.SecretKey
+		if secretKey == "" {
+			now := time.Now
+			if cfg.Now != nil {
+				now = cfg.Now
+			}
+
+			key, err := SelectSecretKey(cfg.Secret, now())
+			if err != nil {
+				return nil, err
+			}
+
+			secretKey = key
+		}
+
+		creds = credentials.NewStaticCredentials(cfg.UserID, secretKey, "")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(cfg.Endpoint),
+		Region:           aws.String(region),
+		Credentials:      creds,
+		S3ForcePathStyle: aws.Bool(true),
+		HTTPClient:       httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := s3.New(sess)
+
+	if cfg.Namespace != "" {
+		client.Handlers.Build.PushBackNamed(request.NamedHandler{
+			Name: NamespaceHandlerName,
+			Fn: func(r *request.Request) {
+				r.HTTPRequest.Header.Set(NamespaceHeaderName, cfg.Namespace)
+			},
+		})
+	}
+
+	return client, nil
+}
+
+// SelectSecretKey returns the newest key of the object user secret that has
+// not expired at now.
+func SelectSecretKey(secret model.ObjectUserSecret, now time.Time) (string, error) {
+	var (
+		selected string
+		newest   time.Time
+	)
+
+	for _, key := range []struct {
+		secret, created, expiry string
+	}{
+		{secret.SecretKey1, secret.KeyTimestamp1, secret.KeyExpiryTimestamp1},
+		{secret.SecretKey2, secret.KeyTimestamp2, secret.KeyExpiryTimestamp2},
+	} {
+		if key.secret == "" {
+			continue
+		}
+
+		if key.expiry != "" {
+			expiry, err := time.Parse(TimestampLayout, key.expiry)
+			if err != nil {
+				return "", err
+			}
+
+			if !now.Before(expiry) {
+				continue
+			}
+		}
+
+		var created time.Time
+
+		if key.created != "" {
+			parsed, err := time.Parse(TimestampLayout, key.created)
+			if err != nil {
+				return "", err
+			}
+
+			created = parsed
+		}
+
+		if selected == "" || created.After(newest) {
+			selected, newest = key.secret, created
+		}
+	}
+
+	if selected == "" {
+		return "", ErrNoValidKey
+	}
+
+	return selected, nil
+}
+
+// ShareTLS returns an HTTP client using the TLS configuration of the
+// management API client, with its own connection pool.
+func ShareTLS(mgmt *http.Client) *http.Client {
+	if mgmt == nil {
+		return http.DefaultClient
+	}
+
+	shared := *mgmt
+
+	if transport, ok := mgmt.Transport.(*http.Transport); ok {
+		shared.Transport = transport.Clone()
+	}
+
+	return &shared
+}
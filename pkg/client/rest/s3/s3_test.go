@@ -0,0 +1,179 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awsS3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/s3"
+)
+
+// RoundTripFunc is a transport mock that makes a fake HTTP response locally.
+type RoundTripFunc func(req *http.Request) *http.Response
+
+// RoundTrip mocks an http request and returns an http response.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+var fixtureSecret = model.ObjectUserSecret{
+	SecretKey1:          "old-key",
+	KeyTimestamp1:       "2019-11-25 09:56:32.364",
+	KeyExpiryTimestamp1: "2020-01-01 00:00:00.000",
+	SecretKey2:          "new-key",
+	KeyTimestamp2:       "2019-12-25 09:56:32.364",
+}
+
+func TestS3(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"SelectSecretKey": testSelectSecretKey,
+		"New":             testNew,
+		"NewErrors":       testNewErrors,
+		"NewCredentials":  testNewCredentials,
+		"ShareTLS":        testShareTLS,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testSelectSecretKey(t *testing.T) {
+	before := time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// The newest key wins while both are valid.
+	key, err := s3.SelectSecretKey(fixtureSecret, before)
+	require.NoError(t, err)
+	assert.Equal(t, "new-key", key)
+
+	// The newest key expired, so the older one is used.
+	secret := fixtureSecret
+	secret.KeyExpiryTimestamp1 = ""
+	secret.KeyExpiryTimestamp2 = "2020-01-01 00:00:00.000"
+
+	key, err = s3.SelectSecretKey(secret, after)
+	require.NoError(t, err)
+	assert.Equal(t, "old-key", key)
+
+	secret.KeyExpiryTimestamp1 = "2020-01-01 00:00:00.000"
+
+	_, err = s3.SelectSecretKey(secret, after)
+	require.ErrorIs(t, err, s3.ErrNoValidKey)
+
+	_, err = s3.SelectSecretKey(model.ObjectUserSecret{}, after)
+	require.ErrorIs(t, err, s3.ErrNoValidKey)
+
+	_, err = s3.SelectSecretKey(model.ObjectUserSecret{SecretKey1: "key", KeyTimestamp1: "yesterday"}, after)
+	require.Error(t, err)
+}
+
+func testNew(t *testing.T) {
+	// A CA bundle from the environment cannot be loaded into the mock transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	var last *http.Request
+
+	httpClient := &http.Client{Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+		last = req
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/xml"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`<ListAllMyBucketsResult><Buckets><Bucket><Name>b1</Name></Bucket></Buckets></ListAllMyBucketsResult>`)),
+		}
+	})}
+
+	client, err := s3.New(s3.Config{
+		Endpoint:   "https://objectstore:9021",
+		UserID:     "user1",
+		Namespace:  "ns1",
+		Secret:     fixtureSecret,
+		HTTPClient: httpClient,
+		Now:        func() time.Time { return time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC) },
+	})
+	require.NoError(t, err)
+
+	out, err := client.ListBuckets(&awsS3.ListBucketsInput{})
+	require.NoError(t, err)
+	require.Len(t, out.Buckets, 1)
+	assert.Equal(t, "b1", aws.StringValue(out.Buckets[0].Name))
+	assert.Equal(t, "ns1", last.Header.Get(s3.NamespaceHeaderName))
+	assert.Contains(t, last.Header.Get("Authorization"), "Credential=user1/")
+	assert.Contains(t, last.Header.Get("Authorization"), "/"+s3.DefaultRegion+"/s3/")
+
+	_, _ = client.HeadBucket(&awsS3.HeadBucketInput{Bucket: aws.String("b1")})
+	assert.Equal(t, "objectstore:9021", last.URL.Host)
+	assert.Equal(t, "/b1", last.URL.Path)
+}
+
+func testNewErrors(t *testing.T) {
+	_, err := s3.New(s3.Config{UserID: "user1", SecretKey: "key"})
+	require.ErrorIs(t, err, s3.ErrMissingEndpoint)
+
+	_, err = s3.New(s3.Config{Endpoint: "https://objectstore:9021", SecretKey: "key"})
+	require.ErrorIs(t, err, s3.ErrMissingUserID)
+
+	_, err = s3.New(s3.Config{Endpoint: "https://objectstore:9021", UserID: "user1"})
+	require.ErrorIs(t, err, s3.ErrNoValidKey)
+}
+
+func testNewCredentials(t *testing.T) {
+	// A CA bundle from the environment cannot be loaded into the mock transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	var last *http.Request
+
+	httpClient := &http.Client{Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+		last = req
+
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}
+	})}
+
+	// Credentials replace the keys of the object user, so no user is needed.
+	client, err := s3.New(s3.Config{
+		Endpoint:    "https://objectstore:9021",
+		Credentials: credentials.NewStaticCredentials("ASIATEMP", "temp-secret", "session-token"),
+		HTTPClient:  httpClient,
+	})
+	require.NoError(t, err)
+
+	_, err = client.HeadBucket(&awsS3.HeadBucketInput{Bucket: aws.String("b1")})
+	require.NoError(t, err)
+	assert.Contains(t, last.Header.Get("Authorization"), "Credential=ASIATEMP/")
+	assert.Equal(t, "session-token", last.Header.Get("X-Amz-Security-Token"))
+}
+
+func testShareTLS(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "objectscale", MinVersion: tls.VersionTLS12}
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+	mgmt := &http.Client{Transport: transport, Timeout: time.Minute}
+
+	shared := s3.ShareTLS(mgmt)
+
+	require.IsType(t, &http.Transport{}, shared.Transport)
+	assert.NotSame(t, transport, shared.Transport)
+	assert.Equal(t, "objectscale", shared.Transport.(*http.Transport).TLSClientConfig.ServerName)
+	assert.Equal(t, time.Minute, shared.Timeout)
+	assert.Same(t, http.DefaultClient, s3.ShareTLS(nil))
+}
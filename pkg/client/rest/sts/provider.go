@@ -0,0 +1,109 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// ProviderName is the name of the credentials provider.
+const ProviderName = "ObjectScaleSTSProvider"
+
+// DefaultExpiryWindow is how long before they expire temporary credentials
+// are refreshed by default.
+const DefaultExpiryWindow = time.Minute
+
+// ErrNoCredentials is returned when STS returns no credentials.
+var ErrNoCredentials = errors.New("no credentials returned")
+
+// Provider is a credentials provider refreshing temporary credentials
+// obtained from STS before they expire. Use it with credentials.NewCredentials,
+// which caches the credentials and is safe for concurrent use.
+type Provider struct {
+	credentials.Expiry
+
+	// Assume obtains new temporary credentials
+	Assume func(ctx context.Context) (*sts.Credentials, error)
+
+	// ExpiryWindow is how long before they expire the credentials are
+	// refreshed; DefaultExpiryWindow if zero
+	ExpiryWindow time.Duration
+}
+
+var (
+	_ credentials.Provider            = (*Provider)(nil) // interface guard
+	_ credentials.ProviderWithContext = (*Provider)(nil) // interface guard
+)
+
+// NewAssumeRoleProvider returns a provider of temporary credentials of the
+// role.
+func NewAssumeRoleProvider(c *Client, roleARN, sessionName string, duration time.Duration) *Provider {
+	return &Provider{
+		Assume: func(ctx context.Context) (*sts.Credentials, error) {
+			return c.AssumeRole(ctx, roleARN, sessionName, duration)
+		},
+	}
+}
+
+// NewWebIdentityProvider returns a provider of temporary credentials of the
+// role, authenticated by the OIDC token returned by token. The token is read
+// on every refresh, so that rotated tokens are picked up.
+func NewWebIdentityProvider(c *Client, roleARN, sessionName string, token func() (string, error), duration time.Duration) *Provider {
+	return &Provider{
+		Assume: func(ctx context.Context) (*sts.Credentials, error) {
+			webIdentityToken, err := token()
+			if err != nil {
+				return nil, err
+			}
+
+			return c.AssumeRoleWithWebIdentity(ctx, roleARN, sessionName, webIdentityToken, duration)
+		},
+	}
+}
+
+// Retrieve implements the credentials.Provider interface.
+func (p *Provider) Retrieve() (credentials.Value, error) {
+	return p.RetrieveWithContext(aws.BackgroundContext())
+}
+
+// RetrieveWithContext implements the credentials.ProviderWithContext interface.
+func (p *Provider) RetrieveWithContext(ctx credentials.Context) (credentials.Value, error) {
+	creds, err := p.Assume(ctx)
+	if err != nil {
+		return credentials.Value{ProviderName: ProviderName}, err
+	}
+
+	if creds == nil {
+		return credentials.Value{ProviderName: ProviderName}, ErrNoCredentials
+	}
+
+	window := p.ExpiryWindow
+	if window == 0 {
+		window = DefaultExpiryWindow
+	}
+
+	p.SetExpiration(aws.TimeValue(creds.Expiration), window)
+
+	return credentials.Value{
+		AccessKeyID:     aws.StringValue(creds.AccessKeyId),
+		SecretAccessKey: aws.StringValue(creds.SecretAccessKey),
+		SessionToken:    aws.StringValue(creds.SessionToken),
+		ProviderName:    ProviderName,
+	}, nil
+}
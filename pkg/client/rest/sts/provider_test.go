@@ -0,0 +1,143 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awsSTS "github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/sts"
+)
+
+func TestProvider(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"Refresh":             testProviderRefresh,
+		"Errors":              testProviderErrors,
+		"AssumeRoleProvider":  testAssumeRoleProvider,
+		"WebIdentityProvider": testWebIdentityProvider,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testProviderRefresh(t *testing.T) {
+	calls := 0
+	expiration := time.Now().Add(time.Hour)
+
+	provider := &sts.Provider{
+		Assume: func(context.Context) (*awsSTS.Credentials, error) {
+			calls++
+
+			return &awsSTS.Credentials{
+				AccessKeyId:     aws.String(fmt.Sprint("ASIA", calls)),
+				SecretAccessKey: aws.String("secret"),
+				SessionToken:    aws.String("token"),
+				Expiration:      aws.Time(expiration),
+			}, nil
+		},
+	}
+	creds := credentials.NewCredentials(provider)
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA1", value.AccessKeyID)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.Equal(t, sts.ProviderName, value.ProviderName)
+
+	// Valid credentials are cached.
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA1", value.AccessKeyID)
+	assert.Equal(t, 1, calls)
+
+	// Credentials within the expiry window are refreshed.
+	expiration = time.Now().Add(30 * time.Second)
+	creds.Expire()
+
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA2", value.AccessKeyID)
+	assert.True(t, provider.IsExpired())
+
+	value, err = creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIA3", value.AccessKeyID)
+}
+
+func testProviderErrors(t *testing.T) {
+	errAssume := errors.New("assume failed")
+
+	provider := &sts.Provider{Assume: func(context.Context) (*awsSTS.Credentials, error) { return nil, errAssume }}
+	_, err := provider.Retrieve()
+	require.ErrorIs(t, err, errAssume)
+
+	provider = &sts.Provider{Assume: func(context.Context) (*awsSTS.Credentials, error) { return nil, nil }}
+	_, err = provider.Retrieve()
+	require.ErrorIs(t, err, sts.ErrNoCredentials)
+}
+
+func testAssumeRoleProvider(t *testing.T) {
+	f := &fakeSTS{expiration: time.Now().Add(time.Hour)}
+	c := f.client(t, sts.Config{AccountID: "ns1", Authenticator: staticAuth{}})
+
+	creds := credentials.NewCredentials(sts.NewAssumeRoleProvider(c, "urn:ecs:iam::ns1:role/reader", "workload", time.Hour))
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIAAssumeRole", value.AccessKeyID)
+	assert.Equal(t, "3600", f.forms[0].Get("DurationSeconds"))
+}
+
+func testWebIdentityProvider(t *testing.T) {
+	f := &fakeSTS{expiration: time.Now().Add(time.Hour)}
+	c := f.client(t, sts.Config{AccountID: "ns1"})
+
+	tokens := []string{"token1", "token2"}
+	token := func() (string, error) {
+		next := tokens[0]
+		tokens = tokens[1:]
+
+		return next, nil
+	}
+
+	creds := credentials.NewCredentials(sts.NewWebIdentityProvider(c, "urn:ecs:iam::ns1:role/reader", "workload", token, 0))
+
+	value, err := creds.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "ASIAAssumeRoleWithWebIdentity", value.AccessKeyID)
+
+	// The token is read again on refresh.
+	creds.Expire()
+
+	_, err = creds.Get()
+	require.NoError(t, err)
+	require.Len(t, f.forms, 2)
+	assert.Equal(t, "token1", f.forms[0].Get("WebIdentityToken"))
+	assert.Equal(t, "token2", f.forms[1].Get("WebIdentityToken"))
+
+	errToken := errors.New("no token")
+	creds = credentials.NewCredentials(sts.NewWebIdentityProvider(c, "urn:ecs:iam::ns1:role/reader", "workload",
+		func() (string, error) { return "", errToken }, 0))
+
+	_, err = creds.Get()
+	require.ErrorIs(t, err, errToken)
+}
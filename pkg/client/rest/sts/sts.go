@@ -0,0 +1,204 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sts provides functions for obtaining temporary credentials from the
+// ObjectScale STS endpoint.
+//
+// Example function handing short-lived credentials of a role to an S3 client.
+//
+//	func ExampleAssumeRole(roleARN string) {
+//		objClient := client.AuthUser{Gateway: "https://testgateway", Username: "username", Password: "password"}
+//		stsClient, _ := sts.NewClient(sts.Config{
+//			Gateway:       "https://testgateway",
+//			AccountID:     "osaid185e2bf9e8ae35f",
+//			Authenticator: &objClient,
+//		})
+//		creds := credentials.NewCredentials(sts.NewAssumeRoleProvider(stsClient, roleARN, "workload", time.Hour))
+//		s3Client, _ := s3.New(s3.Config{Endpoint: "https://objectstore:9021", Credentials: creds})
+//	}
+package sts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/dell/goobjectscale/pkg/client/rest/client"
+	"github.com/dell/goobjectscale/pkg/client/rest/iam"
+)
+
+// DefaultRegion is the region of the STS client if none is configured.
+const DefaultRegion = iam.DefaultRegion
+
+// ErrMissingGateway is returned when no gateway is configured.
+var ErrMissingGateway = errors.New("missing gateway")
+
+// InjectTokenToSTSClient configure STS client to connect with Objectscale.
+func InjectTokenToSTSClient(clientSts stsiface.STSAPI, clientObjectscale client.Authenticator, httpClient http.Client) error {
+	realSts, ok := clientSts.(*sts.STS)
+	if !ok {
+		return errors.New("invalid sts client")
+	}
+
+	iam.InjectTokenToHandlers(&realSts.Handlers, clientObjectscale, httpClient)
+
+	return nil
+}
+
+// InjectAccountIDToSTSClient configure STS client to connect with Objectscale Account.
+// The account ID of the request context, set with iam.WithAccountID, takes
+// precedence.
+func InjectAccountIDToSTSClient(clientSts stsiface.STSAPI, accountID string) error {
+	realSts, ok := clientSts.(*sts.STS)
+	if !ok {
+		return errors.New("invalid sts client")
+	}
+
+	iam.InjectAccountIDToHandlers(&realSts.Handlers, accountID)
+
+	return nil
+}
+
+// Config is the configuration of an STS client.
+type Config struct {
+	// Gateway is the URL of the ObjectScale gateway; the STS API is served
+	// under its /sts path
+	Gateway string
+
+	// AccountID is the ObjectScale account owning the roles
+	AccountID string
+
+	// Authenticator, if set, obtains the token sent with every request. It is
+	// required by AssumeRole, but not by the federation operations, which are
+	// authenticated by the SAML assertion or web identity token
+	Authenticator client.Authenticator
+
+	// HTTPClient is the client sending requests; http.DefaultClient if nil
+	HTTPClient *http.Client
+
+	// Region is the region of the STS client, DefaultRegion if empty
+	Region string
+}
+
+// Client is an ObjectScale STS client. It translates errors with
+// iam.TranslateError.
+type Client struct {
+	// STS is the underlying STS client, for operations not covered by Client
+	STS stsiface.STSAPI
+}
+
+// NewClient returns an STS client acting on the configured account.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Gateway == "" {
+		return nil, ErrMissingGateway
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:                      aws.String(strings.TrimSuffix(cfg.Gateway, "/") + "/sts"),
+		Region:                        aws.String(region),
+		Credentials:                   credentials.AnonymousCredentials,
+		CredentialsChainVerboseErrors: aws.Bool(true),
+		HTTPClient:                    httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stsClient := sts.New(sess)
+
+	if cfg.Authenticator != nil {
+		if err := InjectTokenToSTSClient(stsClient, cfg.Authenticator, *httpClient); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := InjectAccountIDToSTSClient(stsClient, cfg.AccountID); err != nil {
+		return nil, err
+	}
+
+	return &Client{STS: stsClient}, nil
+}
+
+// durationSeconds returns the duration in seconds, or nil to use the default
+// duration of the role.
+func durationSeconds(d time.Duration) *int64 {
+	if d <= 0 {
+		return nil
+	}
+
+	return aws.Int64(int64(d / time.Second))
+}
+
+// AssumeRole returns temporary credentials of the role, valid for duration or
+// the default duration of the role if zero.
+func (c *Client) AssumeRole(ctx context.Context, roleARN, sessionName string, duration time.Duration) (*sts.Credentials, error) {
+	out, err := c.STS.AssumeRoleWithContext(ctx, &sts.AssumeRoleInput{
+		RoleArn:         aws.String(roleARN),
+		RoleSessionName: aws.String(sessionName),
+		DurationSeconds: durationSeconds(duration),
+	})
+	if err != nil {
+		return nil, iam.TranslateError(err)
+	}
+
+	return out.Credentials, nil
+}
+
+// AssumeRoleWithSAML returns temporary credentials of the role for the user
+// authenticated by the base64-encoded SAML assertion of the identity provider.
+func (c *Client) AssumeRoleWithSAML(ctx context.Context, roleARN, principalARN, samlAssertion string, duration time.Duration) (*sts.Credentials, error) {
+	out, err := c.STS.AssumeRoleWithSAMLWithContext(ctx, &sts.AssumeRoleWithSAMLInput{
+		RoleArn:         aws.String(roleARN),
+		PrincipalArn:    aws.String(principalARN),
+		SAMLAssertion:   aws.String(samlAssertion),
+		DurationSeconds: durationSeconds(duration),
+	})
+	if err != nil {
+		return nil, iam.TranslateError(err)
+	}
+
+	return out.Credentials, nil
+}
+
+// AssumeRoleWithWebIdentity returns temporary credentials of the role for the
+// user authenticated by the OIDC token of the identity provider.
+func (c *Client) AssumeRoleWithWebIdentity(ctx context.Context, roleARN, sessionName, webIdentityToken string, duration time.Duration) (*sts.Credentials, error) {
+	out, err := c.STS.AssumeRoleWithWebIdentityWithContext(ctx, &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          aws.String(roleARN),
+		RoleSessionName:  aws.String(sessionName),
+		WebIdentityToken: aws.String(webIdentityToken),
+		DurationSeconds:  durationSeconds(duration),
+	})
+	if err != nil {
+		return nil, iam.TranslateError(err)
+	}
+
+	return out.Credentials, nil
+}
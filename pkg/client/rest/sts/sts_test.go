@@ -0,0 +1,189 @@
+// Copyright © 2023 Dell Inc. or its subsidiaries. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//      http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sts_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	awsSTS "github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dell/goobjectscale/pkg/client/model"
+	"github.com/dell/goobjectscale/pkg/client/rest/iam"
+	"github.com/dell/goobjectscale/pkg/client/rest/sts"
+)
+
+// RoundTripFunc is a transport mock that makes a fake HTTP response locally.
+type RoundTripFunc func(req *http.Request) *http.Response
+
+// RoundTrip mocks an http request and returns an http response.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// staticAuth is an authenticator holding a fixed token.
+type staticAuth struct{}
+
+func (staticAuth) IsAuthenticated() bool                     { return true }
+func (staticAuth) Login(context.Context, *http.Client) error { return nil }
+func (staticAuth) Token() string                             { return "TESTTOKEN" }
+
+// credentialsBody returns the result of an STS action holding credentials
+// with the access key ID.
+func credentialsBody(action, accessKeyID string, expiration time.Time) string {
+	return `<` + action + `Response><` + action + `Result><Credentials>` +
+		`<AccessKeyId>` + accessKeyID + `</AccessKeyId><SecretAccessKey>secret</SecretAccessKey>` +
+		`<SessionToken>token</SessionToken><Expiration>` + expiration.UTC().Format(time.RFC3339) + `</Expiration>` +
+		`</Credentials></` + action + `Result></` + action + `Response>`
+}
+
+// fakeSTS is a fake STS API recording the requests it receives.
+type fakeSTS struct {
+	requests   []*http.Request
+	forms      []url.Values
+	expiration time.Time
+}
+
+func (f *fakeSTS) client(t *testing.T, cfg sts.Config) *sts.Client {
+	t.Helper()
+
+	// A CA bundle from the environment cannot be loaded into the mock transport.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	cfg.Gateway = "https://testgateway"
+	cfg.HTTPClient = &http.Client{Transport: RoundTripFunc(func(req *http.Request) *http.Response {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+
+		f.requests = append(f.requests, req)
+		f.forms = append(f.forms, form)
+
+		action := form.Get("Action")
+		if form.Get("RoleArn") == "urn:ecs:iam::ns1:role/missing" {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{},
+				Body: io.NopCloser(bytes.NewBufferString(`<ErrorResponse><Error><Type>Sender</Type>` +
+					`<Code>NoSuchEntity</Code><Message>Role not found.</Message></Error></ErrorResponse>`)),
+			}
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewBufferString(credentialsBody(action, "ASIA"+action, f.expiration))),
+		}
+	})}
+
+	c, err := sts.NewClient(cfg)
+	require.NoError(t, err)
+
+	c.STS.(*awsSTS.STS).Retryer = awsclient.NoOpRetryer{}
+
+	return c
+}
+
+func TestSTS(t *testing.T) {
+	for scenario, fn := range map[string]func(t *testing.T){
+		"AssumeRole":                testAssumeRole,
+		"AssumeRoleWithSAML":        testAssumeRoleWithSAML,
+		"AssumeRoleWithWebIdentity": testAssumeRoleWithWebIdentity,
+		"Errors":                    testErrors,
+		"NewClient":                 testNewClient,
+	} {
+		t.Run(scenario, fn)
+	}
+}
+
+func testAssumeRole(t *testing.T) {
+	f := &fakeSTS{expiration: time.Now().Add(time.Hour)}
+	c := f.client(t, sts.Config{AccountID: "ns1", Authenticator: staticAuth{}})
+
+	creds, err := c.AssumeRole(context.TODO(), "urn:ecs:iam::ns1:role/reader", "workload", 15*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "ASIAAssumeRole", aws.StringValue(creds.AccessKeyId))
+
+	require.Len(t, f.requests, 1)
+	assert.Equal(t, "/sts/", f.requests[0].URL.Path)
+	assert.Equal(t, "TESTTOKEN", f.requests[0].Header.Get(iam.SDSHeaderName))
+	assert.Equal(t, "ns1", f.requests[0].Header.Get(iam.AccountIDHeaderName))
+	assert.Empty(t, f.requests[0].Header.Get("Authorization"))
+	assert.Equal(t, "urn:ecs:iam::ns1:role/reader", f.forms[0].Get("RoleArn"))
+	assert.Equal(t, "workload", f.forms[0].Get("RoleSessionName"))
+	assert.Equal(t, "900", f.forms[0].Get("DurationSeconds"))
+
+	// The account of the request context takes precedence.
+	_, err = c.AssumeRole(iam.WithAccountID(context.TODO(), "ns2"), "urn:ecs:iam::ns2:role/reader", "workload", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "ns2", f.requests[1].Header.Get(iam.AccountIDHeaderName))
+	assert.False(t, f.forms[1].Has("DurationSeconds"))
+}
+
+func testAssumeRoleWithSAML(t *testing.T) {
+	f := &fakeSTS{expiration: time.Now().Add(time.Hour)}
+	c := f.client(t, sts.Config{AccountID: "ns1"})
+
+	creds, err := c.AssumeRoleWithSAML(context.TODO(), "urn:ecs:iam::ns1:role/reader",
+		"urn:ecs:iam::ns1:saml-provider/idp", "PHNhbWw+", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "ASIAAssumeRoleWithSAML", aws.StringValue(creds.AccessKeyId))
+
+	// Federation is authenticated by the assertion, without a token.
+	assert.Empty(t, f.requests[0].Header.Get(iam.SDSHeaderName))
+	assert.Equal(t, "ns1", f.requests[0].Header.Get(iam.AccountIDHeaderName))
+	assert.Equal(t, "urn:ecs:iam::ns1:saml-provider/idp", f.forms[0].Get("PrincipalArn"))
+	assert.Equal(t, "PHNhbWw+", f.forms[0].Get("SAMLAssertion"))
+	assert.Equal(t, "3600", f.forms[0].Get("DurationSeconds"))
+}
+
+func testAssumeRoleWithWebIdentity(t *testing.T) {
+	f := &fakeSTS{expiration: time.Now().Add(time.Hour)}
+	c := f.client(t, sts.Config{AccountID: "ns1"})
+
+	creds, err := c.AssumeRoleWithWebIdentity(context.TODO(), "urn:ecs:iam::ns1:role/reader", "workload", "eyJhbGciOi", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "ASIAAssumeRoleWithWebIdentity", aws.StringValue(creds.AccessKeyId))
+	assert.Equal(t, "eyJhbGciOi", f.forms[0].Get("WebIdentityToken"))
+	assert.Equal(t, "workload", f.forms[0].Get("RoleSessionName"))
+}
+
+func testErrors(t *testing.T) {
+	f := &fakeSTS{}
+	c := f.client(t, sts.Config{Authenticator: staticAuth{}})
+
+	_, err := c.AssumeRole(context.TODO(), "urn:ecs:iam::ns1:role/missing", "workload", 0)
+	require.Error(t, err)
+	assert.True(t, model.IsNotFound(err))
+}
+
+func testNewClient(t *testing.T) {
+	_, err := sts.NewClient(sts.Config{})
+	require.ErrorIs(t, err, sts.ErrMissingGateway)
+
+	type invalidSTSClient struct {
+		stsiface.STSAPI
+	}
+
+	require.Error(t, sts.InjectTokenToSTSClient(invalidSTSClient{}, staticAuth{}, http.Client{}))
+	require.Error(t, sts.InjectAccountIDToSTSClient(invalidSTSClient{}, "ns1"))
+}
@@ -123,6 +123,46 @@ func (t *Tenants) Update(ctx context.Context, payload model.TenantUpdate, tenant
 	return nil
 }
 
+// Apply implements the tenants interface.
+func (t *Tenants) Apply(ctx context.Context, payload model.TenantCreate) (*model.Tenant, model.ApplyResult, error) {
+	live, err := t.Get(ctx, payload.AccountID, nil)
+	if model.IsNotFound(err) {
+		created, createErr := t.Create(ctx, payload)
+		if createErr == nil {
+			return created, model.ApplyCreated, nil
+		}
+
+		// no error code is known for an existing tenant, so it may have been
+		// created concurrently: compare with what is there now, if anything
+		live, err = t.Get(ctx, payload.AccountID, nil)
+		if model.IsNotFound(err) {
+			return nil, "", createErr
+		}
+	}
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := payload.CheckImmutable(*live); err != nil {
+		return nil, "", err
+	}
+
+	update, changed := payload.Update(*live)
+	if !changed {
+		return live, model.ApplyUnchanged, nil
+	}
+
+	if err := t.Update(ctx, update, payload.AccountID); err != nil {
+		return nil, "", err
+	}
+
+	live.BucketBlockSize = update.BucketBlockSize
+	live.Alias = update.Alias
+
+	return live, model.ApplyUpdated, nil
+}
+
 // GetQuota implements the tenants interface.
 func (t *Tenants) GetQuota(ctx context.Context, tenantID string, params map[string]string) (*model.TenantQuota, error) {
 	req := client.Request{
@@ -75,6 +75,7 @@ func TestTenants(t *testing.T) {
 		"getQuota":    testGetQuota,
 		"setQuota":    testSetQuota,
 		"deleteQuota": testDeleteQuota,
+		"apply":       testApply,
 	} {
 		t.Run(scenario, func(t *testing.T) {
 			fn(t, clientset)
@@ -86,6 +87,8 @@ func testList(t *testing.T, clientset *rest.ClientSet) {
 	tenants, err := clientset.Tenants().List(context.TODO(), map[string]string{})
 	require.NoError(t, err)
 	assert.Equal(t, len(tenants.Items), 1)
+	assert.Equal(t, "name=*", tenants.Filter)
+	assert.Empty(t, tenants.NextMarker)
 
 	_, err = clientset.Tenants().List(context.TODO(), map[string]string{"a": "b"})
 	require.Error(t, err)
@@ -155,3 +158,35 @@ func testDeleteQuota(t *testing.T, clientset *rest.ClientSet) {
 	err := clientset.Tenants().DeleteQuota(context.TODO(), "test-account")
 	require.NoError(t, err)
 }
+
+func testApply(t *testing.T, clientset *rest.ClientSet) {
+	payload := model.TenantCreate{
+		Alias:     "apply",
+		AccountID: "apply-account-1",
+	}
+
+	_, result, err := clientset.Tenants().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyCreated, result)
+
+	payload.AccountID = "apply-account-2"
+
+	tenant, result, err := clientset.Tenants().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUpdated, result)
+	assert.Equal(t, "apply", tenant.Alias)
+	assert.Equal(t, int64(-1), tenant.BucketBlockSize)
+
+	payload.AccountID = "apply-account-3"
+
+	_, result, err = clientset.Tenants().Apply(context.TODO(), payload)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplyUnchanged, result)
+
+	payload.AccountID = "apply-account-4"
+	payload.ComplianceEnabled = true
+
+	_, _, err = clientset.Tenants().Apply(context.TODO(), payload)
+	require.ErrorIs(t, err, model.ErrImmutableField)
+	assert.ErrorContains(t, err, "is_compliance_enabled")
+}